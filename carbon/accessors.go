@@ -0,0 +1,108 @@
+package carbon
+
+import "time"
+
+//////////////////////////
+// Getters
+/////////////////////////
+
+func (c Carbon) Year() int {
+	return c.t.Year()
+}
+
+func (c Carbon) Month() time.Month {
+	return c.t.Month()
+}
+
+func (c Carbon) Day() int {
+	return c.t.Day()
+}
+
+func (c Carbon) Hour() int {
+	return c.t.Hour()
+}
+
+func (c Carbon) Minute() int {
+	return c.t.Minute()
+}
+
+func (c Carbon) Second() int {
+	return c.t.Second()
+}
+
+func (c Carbon) Nanosecond() int {
+	return c.t.Nanosecond()
+}
+
+func (c Carbon) DayOfWeek() time.Weekday {
+	return c.t.Weekday()
+}
+
+// DayOfYear 1 to 365, or 366 in leap years
+func (c Carbon) DayOfYear() int {
+	return c.t.YearDay()
+}
+
+// WeekOfYear weeks start on WeekStartDay, week 1 is the week containing January 1
+func (c Carbon) WeekOfYear() int {
+	jan1 := time.Date(c.t.Year(), 1, 1, 0, 0, 0, 0, c.t.Location())
+	offset := (int(jan1.Weekday()) - int(c.config().WeekStartDay) + 7) % 7
+
+	return (c.t.YearDay()-1+offset)/7 + 1
+}
+
+// ISOWeek ISO 8601 year and week number, weeks start on Monday
+func (c Carbon) ISOWeek() (year, week int) {
+	return c.t.ISOWeek()
+}
+
+func (c Carbon) DaysInMonth() int {
+	return daysInMonth(c.t.Year(), c.t.Month())
+}
+
+func (c Carbon) IsLeapYear() bool {
+	return daysInMonth(c.t.Year(), time.February) == 29
+}
+
+//////////////////////////
+// Setters
+/////////////////////////
+
+// SetYear days overflow like time.Date, Feb 29 becomes Mar 1 on non-leap years
+func (c Carbon) SetYear(year int) Carbon {
+	return c.SetDate(year, c.t.Month(), c.t.Day())
+}
+
+// SetMonth days overflow like time.Date, Jan 31 set to February becomes Mar 2 or 3
+func (c Carbon) SetMonth(month time.Month) Carbon {
+	return c.SetDate(c.t.Year(), month, c.t.Day())
+}
+
+func (c Carbon) SetDay(day int) Carbon {
+	return c.SetDate(c.t.Year(), c.t.Month(), day)
+}
+
+// SetDate keep the time of day, out of range values are normalized like time.Date
+func (c Carbon) SetDate(year int, month time.Month, day int) Carbon {
+	c.t = time.Date(year, month, day, c.t.Hour(), c.t.Minute(), c.t.Second(), c.t.Nanosecond(), c.t.Location())
+	return c
+}
+
+// SetTime keep the date and reset nanoseconds, out of range values are normalized like time.Date
+func (c Carbon) SetTime(hour, minute, second int) Carbon {
+	year, month, day := c.t.Date()
+	c.t = time.Date(year, month, day, hour, minute, second, 0, c.t.Location())
+	return c
+}
+
+func (c Carbon) SetHour(hour int) Carbon {
+	return c.SetTime(hour, c.t.Minute(), c.t.Second())
+}
+
+func (c Carbon) SetMinute(minute int) Carbon {
+	return c.SetTime(c.t.Hour(), minute, c.t.Second())
+}
+
+func (c Carbon) SetSecond(second int) Carbon {
+	return c.SetTime(c.t.Hour(), c.t.Minute(), second)
+}
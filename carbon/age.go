@@ -0,0 +1,73 @@
+package carbon
+
+import "time"
+
+// Age whole years from carbon to now
+func (c Carbon) Age() int {
+	return c.AgeAt(c.getFactory().Now())
+}
+
+// AgeAt whole years from carbon to other, compared by date in the timezone of carbon,
+// 0 if other is before carbon. Feb 29 anniversaries fall on March 1 in common years,
+// the same day AddYears lands on
+func (c Carbon) AgeAt(other Carbon) int {
+	o := other.t.In(c.t.Location())
+	if o.Before(c.t) {
+		return 0
+	}
+
+	age := o.Year() - c.t.Year()
+	if dateBefore(o, c.anniversary(o.Year())) {
+		age--
+	}
+	if age < 0 {
+		return 0
+	}
+
+	return age
+}
+
+// IsBirthday check if the date of other, today if omitted, is the anniversary of carbon
+func (c Carbon) IsBirthday(other ...Carbon) bool {
+	var o time.Time
+	if len(other) > 0 {
+		o = other[0].t.In(c.t.Location())
+	} else {
+		o = c.getFactory().Now(c.t.Location()).t
+	}
+
+	a := c.anniversary(o.Year())
+	return !dateBefore(o, a) && !dateBefore(a, o)
+}
+
+// NextAnniversary start of the next anniversary of carbon, today if it is the anniversary
+func (c Carbon) NextAnniversary() Carbon {
+	now := c.getFactory().Now(c.t.Location()).t
+	a := c.anniversary(now.Year())
+	if dateBefore(a, now) {
+		a = c.anniversary(now.Year() + 1)
+	}
+
+	c.t = a
+	return c
+}
+
+// anniversary start of the anniversary day of carbon in year
+func (c Carbon) anniversary(year int) time.Time {
+	_, month, day := c.t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, c.t.Location())
+}
+
+// dateBefore check if the date of a is before the date of b, ignoring the time of day
+func dateBefore(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	if ay != by {
+		return ay < by
+	}
+	if am != bm {
+		return am < bm
+	}
+
+	return ad < bd
+}
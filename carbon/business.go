@@ -0,0 +1,318 @@
+package carbon
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxBusinessDaySearch stop searching for a business day after ten years,
+// guarding against calendars where every day is a weekend or holiday
+const maxBusinessDaySearch = 3660
+
+// HolidayRule recurring holiday, reports if the day of c is a holiday
+type HolidayRule func(c Carbon) bool
+
+// AnnualHoliday holiday on the same month and day every year
+func AnnualHoliday(month time.Month, day int) HolidayRule {
+	return func(c Carbon) bool {
+		_, m, d := c.t.Date()
+		return m == month && d == day
+	}
+}
+
+// NthWeekdayHoliday holiday on the nth weekday of month every year,
+// negative n counts from the end of month, e.g. -1 for the last Monday of May
+func NthWeekdayHoliday(month time.Month, n int, weekday time.Weekday) HolidayRule {
+	return func(c Carbon) bool {
+		_, m, d := c.t.Date()
+		if m != month || c.t.Weekday() != weekday {
+			return false
+		}
+		if n > 0 {
+			return (d-1)/7+1 == n
+		}
+
+		return (daysInMonth(c.t.Year(), m)-d)/7+1 == -n
+	}
+}
+
+// calendarDate date part of a carbon in its own timezone
+type calendarDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dateOf(c Carbon) calendarDate {
+	y, m, d := c.t.Date()
+	return calendarDate{y, m, d}
+}
+
+// BusinessCalendar weekends and holidays used to skip non business days
+type BusinessCalendar struct {
+	weekend  map[time.Weekday]bool
+	holidays map[calendarDate]bool
+	rules    []HolidayRule
+	mu       sync.RWMutex
+}
+
+// NewBusinessCalendar calendar with Saturday and Sunday as weekend and no holidays
+func NewBusinessCalendar() *BusinessCalendar {
+	return &BusinessCalendar{
+		weekend:  map[time.Weekday]bool{time.Saturday: true, time.Sunday: true},
+		holidays: make(map[calendarDate]bool),
+	}
+}
+
+// SetWeekend replace the weekend days
+func (bc *BusinessCalendar) SetWeekend(days ...time.Weekday) *BusinessCalendar {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.weekend = make(map[time.Weekday]bool, len(days))
+	for _, d := range days {
+		bc.weekend[d] = true
+	}
+	return bc
+}
+
+// AddHolidays add static holidays, only the date part is used
+func (bc *BusinessCalendar) AddHolidays(dates ...Carbon) *BusinessCalendar {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	for _, d := range dates {
+		bc.holidays[dateOf(d)] = true
+	}
+	return bc
+}
+
+// AddHolidayRules add recurring holidays
+func (bc *BusinessCalendar) AddHolidayRules(rules ...HolidayRule) *BusinessCalendar {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.rules = append(bc.rules, rules...)
+	return bc
+}
+
+func (bc *BusinessCalendar) IsWeekend(c Carbon) bool {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.weekend[c.t.Weekday()]
+}
+
+func (bc *BusinessCalendar) IsHoliday(c Carbon) bool {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	if bc.holidays[dateOf(c)] {
+		return true
+	}
+	for _, rule := range bc.rules {
+		if rule(c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (bc *BusinessCalendar) IsBusinessDay(c Carbon) bool {
+	return !bc.IsWeekend(c) && !bc.IsHoliday(c)
+}
+
+// NextBusinessDay first business day after c, keeping the time of day,
+// an invalid carbon is returned if there is none within ten years
+func (bc *BusinessCalendar) NextBusinessDay(c Carbon) Carbon {
+	return bc.seek(c, 1)
+}
+
+// PreviousBusinessDay last business day before c, keeping the time of day,
+// an invalid carbon is returned if there is none within ten years
+func (bc *BusinessCalendar) PreviousBusinessDay(c Carbon) Carbon {
+	return bc.seek(c, -1)
+}
+
+// AddBusinessDays move c by n business days, keeping the time of day,
+// negative n moves backwards, an invalid carbon is returned if a business day can not be found
+func (bc *BusinessCalendar) AddBusinessDays(c Carbon, n int) Carbon {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for i := 0; i < n && c.Valid; i++ {
+		c = bc.seek(c, step)
+	}
+
+	return c
+}
+
+func (bc *BusinessCalendar) SubBusinessDays(c Carbon, n int) Carbon {
+	return bc.AddBusinessDays(c, -n)
+}
+
+// DiffInBusinessDays business days after from up to and including to,
+// negative if to is before from
+func (bc *BusinessCalendar) DiffInBusinessDays(from, to Carbon) int {
+	start, end := from.StartOfDay(), to.In(from.t.Location()).StartOfDay()
+	sign := 1
+	if end.Lt(start) {
+		start, end, sign = end, start, -1
+	}
+
+	count := 0
+	for d := start.AddDay(); d.Lte(end); d = d.AddDay() {
+		if bc.IsBusinessDay(d) {
+			count++
+		}
+	}
+
+	return sign * count
+}
+
+func (bc *BusinessCalendar) seek(c Carbon, step int) Carbon {
+	for i := 0; i < maxBusinessDaySearch; i++ {
+		c = c.AddDays(step)
+		if bc.IsBusinessDay(c) {
+			return c
+		}
+	}
+
+	return Carbon{}
+}
+
+// LoadJSON load holidays from JSON like
+//
+//	{"weekend": [0, 6], "holidays": ["2024-02-10"], "annual": ["01-01", "12-25"]}
+//
+// weekend is optional and uses time.Weekday numbers, a plain array of dates is also accepted
+func (bc *BusinessCalendar) LoadJSON(r io.Reader) error {
+	var raw json.RawMessage
+	if e := json.NewDecoder(r).Decode(&raw); e != nil {
+		return e
+	}
+
+	var file struct {
+		Weekend  []time.Weekday `json:"weekend"`
+		Holidays []string       `json:"holidays"`
+		Annual   []string       `json:"annual"`
+	}
+	if e := json.Unmarshal(raw, &file.Holidays); e != nil {
+		if e := json.Unmarshal(raw, &file); e != nil {
+			return e
+		}
+	}
+
+	if file.Weekend != nil {
+		bc.SetWeekend(file.Weekend...)
+	}
+	for _, h := range file.Holidays {
+		d, e := time.Parse("2006-01-02", h)
+		if e != nil {
+			return e
+		}
+		bc.AddHolidays(New(d, time.UTC))
+	}
+	for _, a := range file.Annual {
+		d, e := time.Parse("01-02", a)
+		if e != nil {
+			return e
+		}
+		bc.AddHolidayRules(AnnualHoliday(d.Month(), d.Day()))
+	}
+
+	return nil
+}
+
+// LoadICS load all-day events from an iCalendar file as holidays, every day from DTSTART
+// up to the exclusive DTEND, events with RRULE:FREQ=YEARLY are added as annual holidays
+func (bc *BusinessCalendar) LoadICS(r io.Reader) error {
+	var (
+		lines   []string
+		scanner = bufio.NewScanner(r)
+	)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		// folded lines continue with a leading space or tab
+		if n := len(lines); n > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[n-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if e := scanner.Err(); e != nil {
+		return e
+	}
+
+	var (
+		inEvent    bool
+		start, end string
+		yearly     bool
+	)
+	for _, line := range lines {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		prop, _, _ := strings.Cut(name, ";")
+
+		switch strings.ToUpper(prop) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent, start, end, yearly = true, "", "", false
+			}
+		case "DTSTART":
+			if inEvent {
+				start = value
+			}
+		case "DTEND":
+			if inEvent {
+				end = value
+			}
+		case "RRULE":
+			if inEvent && strings.Contains(strings.ToUpper(value), "FREQ=YEARLY") {
+				yearly = true
+			}
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			first, e := icsDate("DTSTART", start)
+			if e != nil {
+				return e
+			}
+			last := first
+			if end != "" {
+				d, e := icsDate("DTEND", end)
+				if e != nil {
+					return e
+				}
+				// DTEND is exclusive, an event ending on its start day still covers it
+				if d.After(first) {
+					last = d.AddDate(0, 0, -1)
+				}
+			}
+			for d := first; !d.After(last); d = d.AddDate(0, 0, 1) {
+				if yearly {
+					bc.AddHolidayRules(AnnualHoliday(d.Month(), d.Day()))
+				} else {
+					bc.AddHolidays(New(d, time.UTC))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// icsDate date of an ics DATE or DATE-TIME value
+func icsDate(prop, value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("carbon: invalid %s %q in ics", prop, value)
+	}
+
+	return time.Parse("20060102", value[:8])
+}
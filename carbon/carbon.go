@@ -2,18 +2,20 @@ package carbon
 
 import (
 	"database/sql/driver"
-	"encoding/json"
-	"errors"
 	"strings"
 	"sync"
 	"time"
 )
 
+// Package level defaults used by DefaultFactory, assign them during initialization
+// or update them with Configure once goroutines are running
 var (
 	DefaultDateTimeFormat = "2006-01-02 15:04:05"
 	DefaultDateFormat     = "2006-01-02"
 	WeekStartDay          = time.Monday
 	Timezone              = time.Local
+	// FiscalYearStartMonth first month of the fiscal year, used by fiscal quarter helpers
+	FiscalYearStartMonth = time.January
 )
 
 var (
@@ -22,39 +24,24 @@ var (
 		DefaultDateFormat,
 		time.RFC3339,
 	}
-	mu sync.Mutex
+	// mu guards the package level defaults
+	mu sync.RWMutex
 )
 
 func AddParseLoyouts(layouts ...string) {
-	mu.Lock()
-	defer mu.Unlock()
-	ParseLoyouts = append(ParseLoyouts, layouts...)
+	defaultFactory.AddParseLayouts(layouts...)
 }
 
 type Carbon struct {
-	t     time.Time
-	Valid bool
+	t      time.Time
+	locale string
+	// factory created the carbon, nil for DefaultFactory
+	factory *Factory
+	Valid   bool
 }
 
 func (c Carbon) MarshalJSON() ([]byte, error) {
-	year, month, day := c.t.Date()
-	hour := c.t.Hour()
-	minute := c.t.Minute()
-	second := c.t.Second()
-	millisecond := c.t.UnixNano() / int64(time.Millisecond)
-
-	return json.Marshal(map[string]interface{}{
-		"year":      year,
-		"month":     month,
-		"day":       day,
-		"hour":      hour,
-		"minute":    minute,
-		"second":    second,
-		"time":      millisecond,
-		"timezone":  c.t.Location().String(),
-		"datetime":  c.GetDateTimeString(),
-		"timestamp": c.GetTimestamp(),
-	})
+	return c.MarshalJSONFormat(c.config().JSONFormat)
 }
 
 func (c Carbon) String() string {
@@ -69,10 +56,23 @@ func (c Carbon) GetTimestamp() int64 {
 	return c.t.Unix()
 }
 
+func (c Carbon) TimestampMilli() int64 {
+	return c.t.UnixMilli()
+}
+
+func (c Carbon) TimestampMicro() int64 {
+	return c.t.UnixMicro()
+}
+
+// TimestampNano unix nanoseconds, undefined outside the years 1678 to 2262
+func (c Carbon) TimestampNano() int64 {
+	return c.t.UnixNano()
+}
+
 func (c Carbon) Format(format ...string) string {
 	var f string
 	if len(format) < 1 {
-		f = DefaultDateTimeFormat
+		f = c.config().DateTimeFormat
 	} else {
 		f = format[0]
 	}
@@ -81,11 +81,11 @@ func (c Carbon) Format(format ...string) string {
 }
 
 func (c Carbon) GetDateTimeString() string {
-	return c.Format(DefaultDateTimeFormat)
+	return c.Format(c.config().DateTimeFormat)
 }
 
 func (c Carbon) GetDateString() string {
-	return c.Format(DefaultDateFormat)
+	return c.Format(c.config().DateFormat)
 }
 
 // ////////////////////////
@@ -99,9 +99,9 @@ func (c Carbon) AddMinute() Carbon {
 	return c.Add(time.Minute)
 }
 
+// StartOfMinute start of the minute on the wall clock, zones with second offsets are honored
 func (c Carbon) StartOfMinute() Carbon {
-	c.t = c.t.Truncate(time.Minute)
-	return c
+	return c.FloorTo(Minute, 1)
 }
 
 func (c Carbon) EndOfMinute() Carbon {
@@ -119,9 +119,14 @@ func (c Carbon) AddHour() Carbon {
 	return c.Add(time.Hour)
 }
 
+// AddRealHours add elapsed hours, same as AddHours, named for symmetry with AddRealDays
+func (c Carbon) AddRealHours(hours int) Carbon {
+	return c.AddHours(hours)
+}
+
+// StartOfHour start of the hour on the wall clock, zones with half hour offsets are honored
 func (c Carbon) StartOfHour() Carbon {
-	c.t = c.t.Truncate(time.Hour)
-	return c
+	return c.FloorTo(Hour, 1)
 }
 
 func (c Carbon) EndOfHour() Carbon {
@@ -132,14 +137,29 @@ func (c Carbon) EndOfHour() Carbon {
 // Day
 /////////////////////////
 
-// AddDays modify day
+// AddDays add calendar days, keeping the wall clock time across DST transitions
 func (c Carbon) AddDays(days int) Carbon {
-	return c.Add(time.Duration(days) * 24 * time.Hour)
+	c.t = c.t.AddDate(0, 0, days)
+	return c
 }
 
-// AddDay add one day
+// AddDay add one calendar day
 func (c Carbon) AddDay() Carbon {
-	return c.Add(24 * time.Hour)
+	return c.AddDays(1)
+}
+
+func (c Carbon) SubDays(days int) Carbon {
+	return c.AddDays(-days)
+}
+
+func (c Carbon) SubDay() Carbon {
+	return c.SubDays(1)
+}
+
+// AddRealDays add elapsed days of exactly 24 hours, the wall clock
+// time may shift across DST transitions
+func (c Carbon) AddRealDays(days int) Carbon {
+	return c.Add(time.Duration(days) * 24 * time.Hour)
 }
 
 // StartOfDay
@@ -170,12 +190,16 @@ func (c Carbon) AddWeek() Carbon {
 	return c.AddWeeks(1)
 }
 
+func (c Carbon) SubWeeks(weeks int) Carbon {
+	return c.AddWeeks(-weeks)
+}
+
 func (c Carbon) StartOfWeek() Carbon {
 	t := c.StartOfDay()
 	weekday := int(t.t.Weekday())
 
-	if WeekStartDay != time.Sunday {
-		weekStartDayInt := int(WeekStartDay)
+	if weekStartDay := c.config().WeekStartDay; weekStartDay != time.Sunday {
+		weekStartDayInt := int(weekStartDay)
 
 		if weekday < weekStartDayInt {
 			weekday = weekday + 7 - weekStartDayInt
@@ -193,8 +217,38 @@ func (c Carbon) EndOfWeek() Carbon {
 	return c
 }
 
+//////////////////////////
+// Month
+/////////////////////////
+
+// AddMonths add calendar months, overflowing into the next month
+// when the day does not exist (Jan 31 + 1 month = Mar 3)
 func (c Carbon) AddMonths(months int) Carbon {
-	return c.Add(time.Duration(months) * 30 * 24 * time.Hour)
+	c.t = c.t.AddDate(0, months, 0)
+	return c
+}
+
+func (c Carbon) AddMonth() Carbon {
+	return c.AddMonths(1)
+}
+
+func (c Carbon) SubMonths(months int) Carbon {
+	return c.AddMonths(-months)
+}
+
+func (c Carbon) SubMonth() Carbon {
+	return c.SubMonths(1)
+}
+
+// AddMonthsNoOverflow add calendar months, clamping the day to
+// the end of the target month (Jan 31 + 1 month = Feb 28/29)
+func (c Carbon) AddMonthsNoOverflow(months int) Carbon {
+	c.t = addMonthsNoOverflow(c.t, months)
+	return c
+}
+
+func (c Carbon) SubMonthsNoOverflow(months int) Carbon {
+	return c.AddMonthsNoOverflow(-months)
 }
 
 func (c Carbon) StartOfMonth() Carbon {
@@ -208,8 +262,127 @@ func (c Carbon) EndOfMonth() Carbon {
 	return c
 }
 
+//////////////////////////
+// Quarter
+/////////////////////////
+
+// Quarter calendar quarter, 1 to 4
+func (c Carbon) Quarter() int {
+	return (int(c.t.Month())-1)/3 + 1
+}
+
+func (c Carbon) AddQuarters(quarters int) Carbon {
+	return c.AddMonths(3 * quarters)
+}
+
+func (c Carbon) AddQuarter() Carbon {
+	return c.AddQuarters(1)
+}
+
+func (c Carbon) SubQuarters(quarters int) Carbon {
+	return c.AddQuarters(-quarters)
+}
+
+func (c Carbon) StartOfQuarter() Carbon {
+	year, month, _ := c.t.Date()
+	c.t = time.Date(year, month-(month-1)%3, 1, 0, 0, 0, 0, c.t.Location())
+	return c
+}
+
+func (c Carbon) EndOfQuarter() Carbon {
+	c.t = c.StartOfQuarter().t.AddDate(0, 3, 0).Add(-time.Nanosecond)
+	return c
+}
+
+// FiscalQuarter quarter of the fiscal year starting at FiscalYearStartMonth, 1 to 4
+func (c Carbon) FiscalQuarter() int {
+	return c.fiscalMonthOffset()/3 + 1
+}
+
+func (c Carbon) StartOfFiscalQuarter() Carbon {
+	year, month, _ := c.t.Date()
+	c.t = time.Date(year, month-time.Month(c.fiscalMonthOffset()%3), 1, 0, 0, 0, 0, c.t.Location())
+	return c
+}
+
+func (c Carbon) EndOfFiscalQuarter() Carbon {
+	c.t = c.StartOfFiscalQuarter().t.AddDate(0, 3, 0).Add(-time.Nanosecond)
+	return c
+}
+
+func (c Carbon) StartOfFiscalYear() Carbon {
+	year, month, _ := c.t.Date()
+	c.t = time.Date(year, month-time.Month(c.fiscalMonthOffset()), 1, 0, 0, 0, 0, c.t.Location())
+	return c
+}
+
+func (c Carbon) EndOfFiscalYear() Carbon {
+	c.t = c.StartOfFiscalYear().t.AddDate(1, 0, 0).Add(-time.Nanosecond)
+	return c
+}
+
+// fiscalMonthOffset months elapsed since the start of the fiscal year
+func (c Carbon) fiscalMonthOffset() int {
+	return (int(c.t.Month()) - int(c.config().FiscalYearStartMonth) + 12) % 12
+}
+
+//////////////////////////
+// Year
+/////////////////////////
+
+// AddYears add calendar years, Feb 29 overflows into Mar 1 on non-leap years
 func (c Carbon) AddYears(years int) Carbon {
-	return c.Add(time.Duration(years) * 365 * 24 * time.Hour)
+	c.t = c.t.AddDate(years, 0, 0)
+	return c
+}
+
+func (c Carbon) AddYear() Carbon {
+	return c.AddYears(1)
+}
+
+func (c Carbon) SubYears(years int) Carbon {
+	return c.AddYears(-years)
+}
+
+func (c Carbon) SubYear() Carbon {
+	return c.SubYears(1)
+}
+
+// AddYearsNoOverflow add calendar years, Feb 29 is clamped to Feb 28 on non-leap years
+func (c Carbon) AddYearsNoOverflow(years int) Carbon {
+	return c.AddMonthsNoOverflow(12 * years)
+}
+
+func (c Carbon) SubYearsNoOverflow(years int) Carbon {
+	return c.AddYearsNoOverflow(-years)
+}
+
+//////////////////////////
+// Decade and century
+/////////////////////////
+
+// StartOfDecade decades start at years ending with 0, e.g. 2020
+func (c Carbon) StartOfDecade() Carbon {
+	year := c.t.Year()
+	c.t = time.Date(year-year%10, 1, 1, 0, 0, 0, 0, c.t.Location())
+	return c
+}
+
+func (c Carbon) EndOfDecade() Carbon {
+	c.t = c.StartOfDecade().t.AddDate(10, 0, 0).Add(-time.Nanosecond)
+	return c
+}
+
+// StartOfCentury centuries start at years ending with 01, e.g. 2001
+func (c Carbon) StartOfCentury() Carbon {
+	year := c.t.Year()
+	c.t = time.Date(year-(year-1)%100, 1, 1, 0, 0, 0, 0, c.t.Location())
+	return c
+}
+
+func (c Carbon) EndOfCentury() Carbon {
+	c.t = c.StartOfCentury().t.AddDate(100, 0, 0).Add(-time.Nanosecond)
+	return c
 }
 
 func (c Carbon) StartOfYear() Carbon {
@@ -233,22 +406,79 @@ func (c Carbon) Add(sec time.Duration) Carbon {
 }
 
 func (c Carbon) Clone() Carbon {
-	return New(c.t)
+	cl := c.getFactory().New(c.t, c.t.Location())
+	cl.locale = c.locale
+	return cl
+}
+
+// In convert to the given location, keeping the same instant
+func (c Carbon) In(loc *time.Location) Carbon {
+	c.t = c.t.In(loc)
+	return c
+}
+
+func (c Carbon) UTC() Carbon {
+	c.t = c.t.UTC()
+	return c
+}
+
+func (c Carbon) Local() Carbon {
+	c.t = c.t.Local()
+	return c
+}
+
+// Timezone get the location of carbon
+func (c Carbon) Timezone() *time.Location {
+	return c.t.Location()
+}
+
+// addMonthsNoOverflow add calendar months, clamping the day to the last day of the target month
+func addMonthsNoOverflow(t time.Time, months int) time.Time {
+	year, month, day := t.Date()
+	hour, minute, second := t.Clock()
+	loc := t.Location()
+
+	target := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, loc)
+	if last := daysInMonth(target.Year(), target.Month()); day > last {
+		day = last
+	}
+
+	return time.Date(target.Year(), target.Month(), day, hour, minute, second, t.Nanosecond(), loc)
 }
 
+func daysInMonth(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Scan assigns a value from a database driver, NULL and empty strings are scanned as invalid carbon,
+// int64 is read as unix seconds, or milliseconds when its magnitude is 1e11 or greater
 func (c *Carbon) Scan(src interface{}) (e error) {
-	if bv, ok := src.([]byte); ok {
-		*c, e = Parse(string(bv), Timezone)
+	if src == nil {
+		*c = Carbon{}
 		return
 	}
 
+	if bv, ok := src.([]byte); ok {
+		src = string(bv)
+	}
+
 	if sv, ok := src.(string); ok {
-		*c, e = Parse(sv, Timezone)
+		if strings.TrimSpace(sv) == "" {
+			*c = Carbon{}
+			return
+		}
+		*c, e = c.getFactory().Parse(sv, nil)
+		return
+	}
+
+	if n, ok := src.(int64); ok {
+		*c = c.getFactory().fromEpoch(n)
 		return
 	}
 
 	if ti, ok := src.(time.Time); ok {
 		c.t = ti
+		c.Valid = true
 		return
 	}
 
@@ -256,55 +486,98 @@ func (c *Carbon) Scan(src interface{}) (e error) {
 }
 
 func (c *Carbon) ScanInput(data []byte) error {
+	if string(data) == "null" {
+		*c = Carbon{}
+		return nil
+	}
+
 	return c.Scan(data)
 }
 
+// Value returns NULL for invalid carbon
 func (c Carbon) Value() (driver.Value, error) {
+	if !c.Valid {
+		return nil, nil
+	}
+
 	return c.GetDateTimeString(), nil
 }
 
+func (c Carbon) IsValid() bool {
+	return c.Valid
+}
+
+// IsZero check if the underlying time is the zero time instant
+func (c Carbon) IsZero() bool {
+	return c.t.IsZero()
+}
+
 ////////////////////////
 //  Public functions  //
 ////////////////////////
 
 func Now(tz ...*time.Location) Carbon {
-	return New(time.Now(), tz...)
+	return defaultFactory.Now(tz...)
 }
 
 func New(t time.Time, tz ...*time.Location) Carbon {
-	if len(tz) > 0 && tz[0] != nil {
-		t.In(tz[0])
-	} else {
-		t.In(Timezone)
+	return defaultFactory.New(t, tz...)
+}
 
-	}
+func FromTimestamp(sec int64, tz ...*time.Location) Carbon {
+	return defaultFactory.FromTimestamp(sec, tz...)
+}
+
+func FromTimestampMilli(msec int64, tz ...*time.Location) Carbon {
+	return defaultFactory.FromTimestampMilli(msec, tz...)
+}
+
+func FromTimestampMicro(usec int64, tz ...*time.Location) Carbon {
+	return defaultFactory.FromTimestampMicro(usec, tz...)
+}
+
+func FromTimestampNano(nsec int64, tz ...*time.Location) Carbon {
+	return defaultFactory.FromTimestampNano(nsec, tz...)
+}
+
+// FromDate create carbon at the start of the given day
+func FromDate(year int, month time.Month, day int, tz ...*time.Location) Carbon {
+	return defaultFactory.FromDate(year, month, day, tz...)
+}
 
-	return Carbon{t: t, Valid: true}
+func FromDateTime(year int, month time.Month, day, hour, min, sec int, tz ...*time.Location) Carbon {
+	return defaultFactory.FromDateTime(year, month, day, hour, min, sec, tz...)
+}
+
+// FromTime create carbon today at the given time of day
+func FromTime(hour, min, sec int, tz ...*time.Location) Carbon {
+	return defaultFactory.FromTime(hour, min, sec, tz...)
 }
 
 func Parse(value string, tz *time.Location, layout ...string) (Carbon, error) {
-	value = strings.TrimSpace(value)
-	if len(layout) < 1 {
-		layout = ParseLoyouts
-	}
-	if tz == nil {
-		tz = Timezone
-	}
+	return defaultFactory.Parse(value, tz, layout...)
+}
 
-	for _, v := range layout {
-		ti, err := time.ParseInLocation(v, value, tz)
-		if err == nil {
-			return New(ti, tz), nil
-		}
+// ParseStrict parse value without trimming, every layout is attempted
+// and an error is returned if layouts match with different instants
+func ParseStrict(value string, tz *time.Location, layout ...string) (Carbon, error) {
+	return defaultFactory.ParseStrict(value, tz, layout...)
+}
+
+// MustParse like Parse but panics on error
+func MustParse(value string, tz *time.Location, layout ...string) Carbon {
+	c, e := Parse(value, tz, layout...)
+	if e != nil {
+		panic(e)
 	}
 
-	return Carbon{}, errors.New("can not parse value to carbon")
+	return c
 }
 
 func Today() Carbon {
-	return Now().StartOfDay()
+	return defaultFactory.Today()
 }
 
 func Tomorrow() Carbon {
-	return Now().AddDay().StartOfDay()
+	return defaultFactory.Tomorrow()
 }
@@ -1,10 +1,18 @@
 package carbon_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/enorith/supports/carbon"
+	"gopkg.in/yaml.v3"
 )
 
 type TS time.Time
@@ -39,3 +47,1509 @@ func TestScan(t *testing.T) {
 
 	t.Log(c)
 }
+
+func TestAddMonths(t *testing.T) {
+	c := carbon.New(time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), time.UTC)
+
+	cases := []struct {
+		got  carbon.Carbon
+		want string
+	}{
+		{c.AddMonths(1), "2024-03-02 10:00:00"},
+		{c.AddMonth(), "2024-03-02 10:00:00"},
+		{c.AddMonths(12), "2025-01-31 10:00:00"},
+		{c.SubMonths(2), "2023-12-01 10:00:00"},
+		{c.AddMonthsNoOverflow(1), "2024-02-29 10:00:00"},
+		{c.AddMonthsNoOverflow(13), "2025-02-28 10:00:00"},
+		{c.AddMonthsNoOverflow(2), "2024-03-31 10:00:00"},
+		{c.SubMonthsNoOverflow(2), "2023-11-30 10:00:00"},
+	}
+
+	for _, cs := range cases {
+		if s := cs.got.GetDateTimeString(); s != cs.want {
+			t.Errorf("expected %s, got %s", cs.want, s)
+		}
+	}
+}
+
+func TestAddYears(t *testing.T) {
+	c := carbon.New(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.UTC)
+
+	cases := []struct {
+		got  carbon.Carbon
+		want string
+	}{
+		{c.AddYears(1), "2025-03-01"},
+		{c.AddYear(), "2025-03-01"},
+		{c.AddYears(4), "2028-02-29"},
+		{c.SubYear(), "2023-03-01"},
+		{c.AddYearsNoOverflow(1), "2025-02-28"},
+		{c.SubYearsNoOverflow(1), "2023-02-28"},
+	}
+
+	for _, cs := range cases {
+		if s := cs.got.GetDateString(); s != cs.want {
+			t.Errorf("expected %s, got %s", cs.want, s)
+		}
+	}
+}
+
+func TestDaysAcrossDST(t *testing.T) {
+	berlin, e := time.LoadLocation("Europe/Berlin")
+	if e != nil {
+		t.Skip(e)
+	}
+
+	spring := carbon.New(time.Date(2024, 3, 30, 12, 0, 0, 0, berlin), berlin)
+	autumn := carbon.New(time.Date(2024, 10, 26, 12, 0, 0, 0, berlin), berlin)
+
+	cases := []struct {
+		got     carbon.Carbon
+		want    string
+		elapsed time.Duration
+		from    carbon.Carbon
+	}{
+		{spring.AddDay(), "2024-03-31 12:00:00", 23 * time.Hour, spring},
+		{spring.AddDays(1), "2024-03-31 12:00:00", 23 * time.Hour, spring},
+		{spring.AddWeek(), "2024-04-06 12:00:00", 7*24*time.Hour - time.Hour, spring},
+		{spring.AddRealDays(1), "2024-03-31 13:00:00", 24 * time.Hour, spring},
+		{spring.AddRealHours(24), "2024-03-31 13:00:00", 24 * time.Hour, spring},
+		{autumn.AddDay(), "2024-10-27 12:00:00", 25 * time.Hour, autumn},
+		{autumn.AddRealDays(1), "2024-10-27 11:00:00", 24 * time.Hour, autumn},
+		{autumn.AddDays(1).SubDay(), "2024-10-26 12:00:00", 0, autumn},
+	}
+
+	for _, cs := range cases {
+		if s := cs.got.GetDateTimeString(); s != cs.want {
+			t.Errorf("expected %s, got %s", cs.want, s)
+		}
+		if d := cs.got.GetTime().Sub(cs.from.GetTime()); d != cs.elapsed {
+			t.Errorf("expected %s elapsed, got %s", cs.elapsed, d)
+		}
+	}
+}
+
+func TestTimezone(t *testing.T) {
+	shanghai, e := time.LoadLocation("Asia/Shanghai")
+	if e != nil {
+		t.Skip(e)
+	}
+	ti := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	c := carbon.New(ti, shanghai)
+	if c.Timezone() != shanghai || c.GetDateTimeString() != "2024-01-01 08:00:00" {
+		t.Errorf("expected Asia/Shanghai 08:00, got %s", c)
+	}
+	if u := c.UTC(); u.Timezone() != time.UTC || !u.GetTime().Equal(ti) {
+		t.Errorf("expected UTC instant, got %s", u)
+	}
+	if l := c.Local(); l.Timezone() != time.Local {
+		t.Errorf("expected local timezone, got %s", l.Timezone())
+	}
+
+	old := carbon.Timezone
+	carbon.Timezone = shanghai
+	defer func() { carbon.Timezone = old }()
+
+	if n := carbon.Now(); n.Timezone() != shanghai {
+		t.Errorf("expected global timezone, got %s", n.Timezone())
+	}
+	if n := carbon.New(ti).In(time.UTC); n.Timezone() != time.UTC {
+		t.Errorf("expected UTC, got %s", n.Timezone())
+	}
+	if p, _ := carbon.Parse("2024-01-01 08:00:00", nil); !p.GetTime().Equal(ti) {
+		t.Errorf("expected %s, got %s", ti, p)
+	}
+}
+
+func TestDiffForHumans(t *testing.T) {
+	base := carbon.New(time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC), time.UTC)
+
+	cases := []struct {
+		got  string
+		want string
+	}{
+		{base.AddMinutes(-3).DiffForHumans(base), "3 minutes before"},
+		{base.AddDays(2).DiffForHumans(base), "2 days after"},
+		{base.AddDays(1).AddHours(3).DiffForHumansWith(carbon.DiffOptions{Parts: 2}, base), "1 day 3 hours after"},
+		{base.AddDays(9).DiffForHumansWith(carbon.DiffOptions{Parts: 3, Absolute: true}, base), "1 week 2 days"},
+		{base.AddMonthsNoOverflow(1).DiffForHumansWith(carbon.DiffOptions{Short: true}, base), "1mo after"},
+		{base.AddYears(1).AddHour().DiffForHumansWith(carbon.DiffOptions{Short: true, Parts: 2}, base), "1y 1h after"},
+		{base.AddHours(-5).DiffForHumansWith(carbon.DiffOptions{Locale: "zh-CN"}, base), "5小时前"},
+		{base.AddDays(1).AddHours(3).DiffForHumansWith(carbon.DiffOptions{Locale: "zh-CN", Parts: 2}, base), "1天3小时后"},
+		{base.DiffForHumans(base), "0 seconds before"},
+		{carbon.Now().AddMinutes(-2).DiffForHumans(), "2 minutes ago"},
+		{carbon.Now().AddDays(2).AddMinute().DiffForHumans(), "in 2 days"},
+		{carbon.Now().DiffForHumans(), "just now"},
+	}
+
+	for _, cs := range cases {
+		if cs.got != cs.want {
+			t.Errorf("expected %q, got %q", cs.want, cs.got)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := carbon.New(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.UTC)
+	b := a.AddDays(2)
+	mid := a.AddDay()
+
+	cases := []struct {
+		name string
+		got  bool
+	}{
+		{"eq", a.Eq(a.In(time.Local))},
+		{"ne", a.Ne(b)},
+		{"gt", b.Gt(a) && !a.Gt(a)},
+		{"gte", b.Gte(a) && a.Gte(a)},
+		{"lt", a.Lt(b) && !a.Lt(a)},
+		{"lte", a.Lte(b) && a.Lte(a)},
+		{"between", mid.Between(b, a, false) && !a.Between(a, b, false) && a.Between(a, b, true)},
+		{"same day", a.IsSameDay(a.EndOfDay()) && !a.IsSameDay(mid)},
+		{"same month", a.IsSameMonth(b) && !a.IsSameMonth(a.SubDay())},
+		{"same year", a.IsSameYear(a.EndOfYear()) && !a.IsSameYear(a.AddYear())},
+		{"min", mid.Min(b, a).Eq(a)},
+		{"max", mid.Max(b, a).Eq(b)},
+	}
+
+	for _, cs := range cases {
+		if !cs.got {
+			t.Errorf("%s failed", cs.name)
+		}
+	}
+}
+
+func TestDiffIn(t *testing.T) {
+	a := carbon.New(time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC), time.UTC)
+
+	cases := []struct {
+		got  int64
+		want int64
+	}{
+		{a.DiffInSeconds(a.AddMinutes(2)), 120},
+		{a.DiffInMinutes(a.AddHours(-2)), -120},
+		{a.DiffInMinutes(a.AddHours(-2), true), 120},
+		{a.DiffInHours(a.AddDays(1).AddMinutes(-1)), 23},
+		{a.DiffInDays(a.AddDays(10)), 10},
+		{a.DiffInDays(a.AddDays(-10)), -10},
+		{a.DiffInWeeks(a.AddDays(15)), 2},
+		{a.DiffInMonths(a.AddMonthsNoOverflow(1)), 1},
+		{a.DiffInMonths(a.AddMonthsNoOverflow(1).SubDay()), 0},
+		{a.DiffInMonths(a.AddMonths(1)), 1},
+		{a.DiffInMonths(a.SubMonths(3), true), 3},
+		{a.DiffInYears(a.AddMonths(23)), 1},
+		{a.DiffInYears(a.SubYears(2)), -2},
+	}
+
+	for i, cs := range cases {
+		if cs.got != cs.want {
+			t.Errorf("case %d: expected %d, got %d", i, cs.want, cs.got)
+		}
+	}
+
+	berlin, e := time.LoadLocation("Europe/Berlin")
+	if e != nil {
+		t.Skip(e)
+	}
+	b := carbon.New(time.Date(2024, 3, 30, 12, 0, 0, 0, berlin), berlin)
+	if d := b.DiffInDays(b.AddDay()); d != 1 {
+		t.Errorf("expected 1 day across DST, got %d", d)
+	}
+}
+
+func TestPeriod(t *testing.T) {
+	start := carbon.New(time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), time.UTC)
+
+	format := func(items []carbon.Carbon) []string {
+		var s []string
+		for _, c := range items {
+			s = append(s, c.GetDateString())
+		}
+		return s
+	}
+
+	cases := []struct {
+		got  []string
+		want []string
+	}{
+		{format(carbon.NewPeriod(start, start.AddDays(3)).ToSlice()), []string{"2024-01-31", "2024-02-01", "2024-02-02", "2024-02-03"}},
+		{format(carbon.NewPeriod(start, start.AddDays(3)).ExcludeStart().ExcludeEnd().ToSlice()), []string{"2024-02-01", "2024-02-02"}},
+		{format(carbon.NewPeriod(start, start.AddMonths(3)).EveryMonths(1).ToSlice()), []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30"}},
+		{format(carbon.NewPeriod(start, start.AddDays(14)).OnWeekdays(time.Monday).ToSlice()), []string{"2024-02-05", "2024-02-12"}},
+		{format(carbon.NewPeriod(start, start.AddDays(14)).AlignToWeek().EveryWeeks(1).ToSlice()), []string{"2024-01-29", "2024-02-05", "2024-02-12"}},
+		{format(carbon.NewPeriod(start, start.AddDay()).Every(12 * time.Hour).ToSlice()), []string{"2024-01-31", "2024-01-31", "2024-02-01"}},
+		{format(carbon.NewPeriod(start, start.AddDays(3)).Every(0).ToSlice()), []string{"2024-01-31"}},
+		{format(carbon.NewPeriod(start.AddDay(), start).ToSlice()), nil},
+	}
+
+	for i, cs := range cases {
+		if strings.Join(cs.got, ",") != strings.Join(cs.want, ",") {
+			t.Errorf("case %d: expected %v, got %v", i, cs.want, cs.got)
+		}
+	}
+
+	var first []carbon.Carbon
+	carbon.NewPeriod(start, start.AddDays(10)).All()(func(c carbon.Carbon) bool {
+		first = append(first, c)
+		return len(first) < 2
+	})
+	if len(first) != 2 {
+		t.Errorf("expected iteration to stop after 2 items, got %d", len(first))
+	}
+
+	var zero carbon.Period
+	if items := zero.ToSlice(); len(items) > 1 {
+		t.Errorf("expected the zero period to step by days, got %v", items)
+	}
+}
+
+func TestQuarter(t *testing.T) {
+	c := carbon.New(time.Date(2024, 11, 15, 10, 30, 0, 0, time.UTC), time.UTC)
+
+	if q := c.Quarter(); q != 4 {
+		t.Errorf("expected quarter 4, got %d", q)
+	}
+
+	old := carbon.FiscalYearStartMonth
+	carbon.FiscalYearStartMonth = time.April
+	defer func() { carbon.FiscalYearStartMonth = old }()
+
+	if q := c.FiscalQuarter(); q != 3 {
+		t.Errorf("expected fiscal quarter 3, got %d", q)
+	}
+
+	cases := []struct {
+		got  carbon.Carbon
+		want string
+	}{
+		{c.StartOfQuarter(), "2024-10-01 00:00:00"},
+		{c.EndOfQuarter(), "2024-12-31 23:59:59"},
+		{c.AddQuarter(), "2025-02-15 10:30:00"},
+		{c.SubQuarters(2), "2024-05-15 10:30:00"},
+		{c.StartOfFiscalQuarter(), "2024-10-01 00:00:00"},
+		{c.EndOfFiscalQuarter(), "2024-12-31 23:59:59"},
+		{c.SubMonths(9).StartOfFiscalQuarter(), "2024-01-01 00:00:00"},
+		{c.StartOfFiscalYear(), "2024-04-01 00:00:00"},
+		{c.SubMonths(9).EndOfFiscalYear(), "2024-03-31 23:59:59"},
+		{c.StartOfDecade(), "2020-01-01 00:00:00"},
+		{c.EndOfDecade(), "2029-12-31 23:59:59"},
+		{c.StartOfCentury(), "2001-01-01 00:00:00"},
+		{c.EndOfCentury(), "2100-12-31 23:59:59"},
+	}
+
+	for _, cs := range cases {
+		if s := cs.got.GetDateTimeString(); s != cs.want {
+			t.Errorf("expected %s, got %s", cs.want, s)
+		}
+	}
+}
+
+func TestParseError(t *testing.T) {
+	_, e := carbon.Parse("2024/13/01", time.UTC)
+
+	var pe *carbon.ParseError
+	if !errors.As(e, &pe) {
+		t.Fatalf("expected *carbon.ParseError, got %T", e)
+	}
+	if pe.Value != "2024/13/01" || len(pe.Layouts) != len(carbon.ParseLoyouts) || len(pe.Errors) != len(pe.Layouts) {
+		t.Errorf("unexpected parse error %#v", pe)
+	}
+
+	var te *time.ParseError
+	if !errors.As(e, &te) || te.Layout != carbon.DefaultDateTimeFormat {
+		t.Errorf("expected underlying *time.ParseError, got %v", te)
+	}
+	t.Log(e)
+
+	// the layouts of the error are a copy of the package defaults
+	pe.Layouts[0] = "modified"
+	if l := carbon.DefaultConfig().ParseLayouts[0]; l == "modified" {
+		t.Error("expected modifying the error to leave the default layouts unchanged")
+	}
+	_, e = carbon.ParseStrict("2024/13/01", time.UTC)
+	if errors.As(e, &pe) {
+		pe.Layouts[0] = "modified"
+	}
+	if l := carbon.DefaultConfig().ParseLayouts[0]; l == "modified" {
+		t.Error("expected modifying the strict error to leave the default layouts unchanged")
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	layouts := []string{"01/02/2006", "02/01/2006"}
+
+	if _, e := carbon.ParseStrict("03/04/2024", time.UTC, layouts...); e == nil {
+		t.Error("expected ambiguous error")
+	} else {
+		t.Log(e)
+	}
+
+	c, e := carbon.ParseStrict("03/25/2024", time.UTC, layouts...)
+	if e != nil || c.GetDateString() != "2024-03-25" {
+		t.Errorf("expected 2024-03-25, got %s %v", c.GetDateString(), e)
+	}
+
+	if _, e := carbon.ParseStrict(" 2024-03-25", time.UTC); e == nil {
+		t.Error("expected strict parse to reject surrounding spaces")
+	}
+
+	if c, e := carbon.ParseStrict("04/04/2024", time.UTC, layouts...); e != nil || c.GetDateString() != "2024-04-04" {
+		t.Errorf("expected equal matches to succeed, got %v", e)
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if c := carbon.MustParse("2024-03-25", time.UTC); c.GetDateString() != "2024-03-25" {
+		t.Errorf("expected 2024-03-25, got %s", c.GetDateString())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	carbon.MustParse("invalid", time.UTC)
+}
+
+func TestParseRelative(t *testing.T) {
+	// Wednesday
+	base := carbon.New(time.Date(2024, 1, 31, 15, 4, 5, 0, time.UTC), time.UTC)
+
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{"now", "2024-01-31 15:04:05"},
+		{"today", "2024-01-31 00:00:00"},
+		{"tomorrow 9am", "2024-02-01 09:00:00"},
+		{"9:30 pm tomorrow", "2024-02-01 21:30:00"},
+		{"yesterday noon", "2024-01-30 12:00:00"},
+		{"+3 days", "2024-02-03 15:04:05"},
+		{"-2weeks", "2024-01-17 15:04:05"},
+		{"2 hours ago", "2024-01-31 13:04:05"},
+		{"1 year 2 months ago", "2022-12-01 15:04:05"},
+		{"next month", "2024-03-02 15:04:05"},
+		{"last year", "2023-01-31 15:04:05"},
+		{"next monday", "2024-02-05 00:00:00"},
+		{"last wednesday", "2024-01-24 00:00:00"},
+		{"wednesday", "2024-01-31 00:00:00"},
+		{"next wednesday at 10:15", "2024-02-07 10:15:00"},
+		{"monday this week", "2024-01-29 00:00:00"},
+		{"friday next week", "2024-02-09 00:00:00"},
+		{"first day of next month", "2024-02-01 15:04:05"},
+		{"last day of next month", "2024-02-29 15:04:05"},
+		{"last day of february 2025 midnight", "2025-02-28 00:00:00"},
+		{"2024-05-06 18:00", "2024-05-06 18:00:00"},
+	}
+
+	for _, cs := range cases {
+		c, e := carbon.ParseRelative(cs.expr, base)
+		if e != nil {
+			t.Errorf("%q: %v", cs.expr, e)
+			continue
+		}
+		if s := c.GetDateTimeString(); s != cs.want {
+			t.Errorf("%q: expected %s, got %s", cs.expr, cs.want, s)
+		}
+	}
+
+	for _, expr := range []string{"", "   ", "ago", "tomorrow ago", "next", "3 bananas", "13pm", "someday"} {
+		if _, e := carbon.ParseRelative(expr, base); e == nil {
+			t.Errorf("%q: expected error", expr)
+		}
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	shanghai, e := time.LoadLocation("Asia/Shanghai")
+	if e != nil {
+		t.Skip(e)
+	}
+	c := carbon.New(time.Date(2024, 3, 5, 10, 30, 0, 0, shanghai), shanghai)
+
+	cases := []struct {
+		format carbon.JSONFormat
+		want   string
+	}{
+		{carbon.FormatRFC3339, `"2024-03-05T10:30:00+08:00"`},
+		{carbon.FormatDateTime, `"2024-03-05 10:30:00"`},
+		{carbon.FormatUnix, `1709605800`},
+		{carbon.FormatUnixMilli, `1709605800000`},
+	}
+
+	for _, cs := range cases {
+		b, e := c.MarshalJSONFormat(cs.format)
+		if e != nil || string(b) != cs.want {
+			t.Errorf("expected %s, got %s %v", cs.want, b, e)
+		}
+	}
+
+	old := carbon.DefaultJSONFormat
+	carbon.DefaultJSONFormat = carbon.FormatUnix
+	defer func() { carbon.DefaultJSONFormat = old }()
+
+	b, _ := json.Marshal(struct {
+		A carbon.Carbon   `json:"a"`
+		B carbon.RFC3339  `json:"b"`
+		C carbon.DateTime `json:"c"`
+	}{c, carbon.RFC3339{Carbon: c}, carbon.DateTime{Carbon: c}})
+	if want := `{"a":1709605800,"b":"2024-03-05T10:30:00+08:00","c":"2024-03-05 10:30:00"}`; string(b) != want {
+		t.Errorf("expected %s, got %s", want, b)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	c := carbon.New(time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC), time.UTC)
+	obj, _ := c.MarshalJSONFormat(carbon.FormatObject)
+
+	inputs := []string{
+		string(obj),
+		`"2024-03-05T10:30:00Z"`,
+		`"2024-03-05T18:30:00+08:00"`,
+		`1709634600`,
+		`1709634600000`,
+	}
+
+	for _, in := range inputs {
+		var got carbon.Carbon
+		if e := json.Unmarshal([]byte(in), &got); e != nil {
+			t.Errorf("%s: %v", in, e)
+			continue
+		}
+		if !got.Eq(c) {
+			t.Errorf("%s: expected %s, got %s", in, c, got)
+		}
+	}
+
+	var w struct {
+		U carbon.UnixMilli `json:"u"`
+	}
+	if e := json.Unmarshal([]byte(`{"u":"2024-03-05T10:30:00Z"}`), &w); e != nil || !w.U.Eq(c) {
+		t.Errorf("expected wrapper to unmarshal, got %s %v", w.U, e)
+	}
+
+	// near the epoch the unit of a wrapper is not guessed
+	epoch := struct {
+		S carbon.Unix      `json:"s"`
+		M carbon.UnixMilli `json:"m"`
+	}{}
+	epoch.S.Carbon = carbon.New(time.Unix(1e11+1, 0), time.UTC)
+	epoch.M.Carbon = carbon.New(time.Unix(86400, 0), time.UTC)
+	b, _ := json.Marshal(epoch)
+	if string(b) != `{"s":100000000001,"m":86400000}` {
+		t.Errorf("unexpected %s", b)
+	}
+	var back struct {
+		S carbon.Unix      `json:"s"`
+		M carbon.UnixMilli `json:"m"`
+	}
+	if e := json.Unmarshal(b, &back); e != nil || !back.S.Eq(epoch.S.Carbon) || !back.M.Eq(epoch.M.Carbon) {
+		t.Errorf("expected round trip, got %s %s %v", back.S, back.M, e)
+	}
+
+	var bad carbon.Carbon
+	if e := json.Unmarshal([]byte(`true`), &bad); e == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestNull(t *testing.T) {
+	c := carbon.Now()
+	if e := c.Scan(nil); e != nil || c.IsValid() || !c.IsZero() {
+		t.Errorf("expected invalid carbon after scanning NULL, got %v %v", c, e)
+	}
+
+	if v, _ := c.Value(); v != nil {
+		t.Errorf("expected NULL value, got %v", v)
+	}
+	if b, _ := json.Marshal(c); string(b) != "null" {
+		t.Errorf("expected null, got %s", b)
+	}
+
+	if e := c.Scan(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)); e != nil || !c.IsValid() {
+		t.Errorf("expected valid carbon after scanning time, got %v", e)
+	}
+	if v, _ := c.Value(); v == nil {
+		t.Error("expected non NULL value")
+	}
+
+	if e := json.Unmarshal([]byte("null"), &c); e != nil || c.IsValid() {
+		t.Errorf("expected invalid carbon after unmarshalling null, got %v", e)
+	}
+
+	var s struct {
+		DeletedAt carbon.Carbon `json:"deleted_at"`
+	}
+	b, _ := json.Marshal(s)
+	if string(b) != `{"deleted_at":null}` {
+		t.Errorf("expected null deleted_at, got %s", b)
+	}
+}
+
+func TestClock(t *testing.T) {
+	defer carbon.ResetClock()
+
+	// Tomorrow reads the package timezone, pin it so the date does not depend on the machine
+	tz := carbon.DefaultConfig().Timezone
+	defer carbon.Configure(func(c *carbon.Config) {
+		c.Timezone = tz
+	})
+	carbon.Configure(func(c *carbon.Config) {
+		c.Timezone = time.UTC
+	})
+
+	fixed := carbon.New(time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC), time.UTC)
+	carbon.SetTestNow(fixed)
+
+	if n := carbon.Now(); !n.Eq(fixed) {
+		t.Errorf("expected %s, got %s", fixed, n)
+	}
+	if s := carbon.Tomorrow().In(time.UTC).GetDateString(); s != "2024-03-06" {
+		t.Errorf("expected 2024-03-06, got %s", s)
+	}
+	if ts := carbon.GetTimestamp(); ts != fixed.GetTimestamp() {
+		t.Errorf("expected %d, got %d", fixed.GetTimestamp(), ts)
+	}
+	if ms := carbon.GetMillisecond(); ms != fixed.GetTimestamp()*1000 {
+		t.Errorf("expected %d, got %d", fixed.GetTimestamp()*1000, ms)
+	}
+
+	carbon.Travel(time.Hour)
+	if n := carbon.Now(); !n.Eq(fixed.AddHour()) {
+		t.Errorf("expected %s, got %s", fixed.AddHour(), n)
+	}
+
+	ctx := carbon.WithClock(context.Background(), carbon.ClockFunc(func() time.Time {
+		return fixed.GetTime().AddDate(1, 0, 0)
+	}))
+	if n := carbon.NowContext(ctx); !n.Eq(fixed.AddYear()) {
+		t.Errorf("expected %s, got %s", fixed.AddYear(), n)
+	}
+	if n := carbon.NowContext(context.Background()); !n.Eq(fixed.AddHour()) {
+		t.Errorf("expected package clock, got %s", n)
+	}
+
+	carbon.ResetClock()
+	frozen := carbon.Freeze()
+	time.Sleep(time.Millisecond)
+	if n := carbon.Now(); !n.Eq(frozen) {
+		t.Errorf("expected frozen %s, got %s", frozen, n)
+	}
+
+	carbon.SetTestNow(carbon.Carbon{})
+	carbon.Travel(-24 * time.Hour)
+	if n := carbon.Now(); !n.Between(frozen.AddHours(-24), frozen.AddHours(-23), true) {
+		t.Errorf("expected clock 24 hours behind, got %s", n)
+	}
+}
+
+func TestFormatPHP(t *testing.T) {
+	c := carbon.New(time.Date(2024, 3, 5, 14, 7, 9, 123456000, time.UTC), time.UTC)
+
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{"Y-m-d H:i:s", "2024-03-05 14:07:09"},
+		{"l, F jS Y", "Tuesday, March 5th 2024"},
+		{"D M j G:i a", "Tue Mar 5 14:07 pm"},
+		{"\\Y\\m\\d: Ymd", "Ymd: 20240305"},
+		{"N w z W t L o", "2 2 64 10 31 1 2024"},
+		{"s.u v", "09.123456 123"},
+		{"U e P", "1709647629 UTC +00:00"},
+	}
+
+	for _, cs := range cases {
+		if s := c.FormatPHP(cs.format); s != cs.want {
+			t.Errorf("%q: expected %q, got %q", cs.format, cs.want, s)
+		}
+	}
+
+	for d, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 11: "11th", 12: "12th", 13: "13th", 22: "22nd", 31: "31st"} {
+		if s := carbon.New(time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC), time.UTC).FormatPHP("jS"); s != want {
+			t.Errorf("expected %s, got %s", want, s)
+		}
+	}
+
+	p, e := carbon.ParsePHP("05/03/2024 14:07", "d/m/Y H:i", time.UTC)
+	if e != nil || p.GetDateTimeString() != "2024-03-05 14:07:00" {
+		t.Errorf("expected 2024-03-05 14:07:00, got %s %v", p.GetDateTimeString(), e)
+	}
+	if _, e := carbon.ParsePHP("5th", "jS", time.UTC); e == nil {
+		t.Error("expected unsupported token error")
+	}
+}
+
+func TestFormatMoment(t *testing.T) {
+	c := carbon.New(time.Date(2024, 3, 5, 9, 7, 9, 123456000, time.UTC), time.UTC)
+
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{"YYYY-MM-DD HH:mm:ss", "2024-03-05 09:07:09"},
+		{"dddd, MMMM Do YYYY", "Tuesday, March 5th 2024"},
+		{"[Today is] ddd, h:m A", "Today is Tue, 9:7 AM"},
+		{"Q Mo DDD DDDD DDDo", "1 3rd 65 065 65th"},
+		{"E d dd W WW Wo GGGG", "2 2 Tu 10 10 10th 2024"},
+		{"H:mm:ss.SSS Z", "9:07:09.123 +00:00"},
+		{"X x", "1709629629 1709629629123"},
+	}
+
+	for _, cs := range cases {
+		if s := c.FormatMoment(cs.format); s != cs.want {
+			t.Errorf("%q: expected %q, got %q", cs.format, cs.want, s)
+		}
+	}
+
+	p, e := carbon.ParseMoment("March 5 2024 9:07 pm", "MMMM D YYYY h:mm a", time.UTC)
+	if e != nil || p.GetDateTimeString() != "2024-03-05 21:07:00" {
+		t.Errorf("expected 2024-03-05 21:07:00, got %s %v", p.GetDateTimeString(), e)
+	}
+	if _, e := carbon.ParseMoment("10", "W", time.UTC); e == nil {
+		t.Error("expected unsupported token error")
+	}
+}
+
+func TestParseEscapedLiterals(t *testing.T) {
+	parse := func(f func(value, format string, tz *time.Location) (carbon.Carbon, error), value, format string) string {
+		c, e := f(value, format, time.UTC)
+		if e != nil {
+			return "error"
+		}
+		return c.GetDateTimeString()
+	}
+
+	cases := []struct {
+		got  string
+		want string
+	}{
+		{parse(carbon.ParseMoment, "v3 2024-03-05", "[v3] YYYY-MM-DD"), "2024-03-05 00:00:00"},
+		{parse(carbon.ParseMoment, "2024 Q1 03-05", "YYYY [Q1] MM-DD"), "2024-03-05 00:00:00"},
+		{parse(carbon.ParseMoment, "Monday 2024-03-05", "[Monday] YYYY-MM-DD"), "2024-03-05 00:00:00"},
+		{parse(carbon.ParseMoment, "v4 2024-03-05", "[v3] YYYY-MM-DD"), "error"},
+		{parse(carbon.ParsePHP, "1 2024-03-05 10:00", `\1 Y-m-d H:i`), "2024-03-05 10:00:00"},
+		{parse(carbon.ParsePHP, "2024-03-05T10:00", `Y-m-d\TH:i`), "2024-03-05 10:00:00"},
+	}
+	for i, cs := range cases {
+		if cs.got != cs.want {
+			t.Errorf("case %d: expected %s, got %s", i, cs.want, cs.got)
+		}
+	}
+}
+
+func TestLocale(t *testing.T) {
+	c := carbon.New(time.Date(2024, 3, 5, 14, 7, 0, 0, time.UTC), time.UTC)
+	zh := c.Locale("zh-CN")
+
+	cases := []struct {
+		got  string
+		want string
+	}{
+		{c.LocaleDateString(), "Tuesday, March 5th 2024"},
+		{c.IsoFormat("ddd, MMM Do h:mm a"), "Tue, Mar 5th 2:07 pm"},
+		{c.TranslatedFormat("l, F jS"), "Tuesday, March 5th"},
+		{zh.LocaleDateString(), "2024年3月5日 星期二"},
+		{zh.LocaleDateTimeString(), "2024年3月5日 星期二 14:07"},
+		{zh.IsoFormat("MMMM ddd dd Ah:mm"), "三月 周二 二 下午2:07"},
+		{zh.TranslatedFormat("Y年n月jS l"), "2024年3月5日 星期二"},
+		{zh.LocaleName(), "zh-CN"},
+		{c.Locale("unknown").IsoFormat("MMMM"), "March"},
+		{zh.DiffForHumans(zh.AddDays(2)), "2天前"},
+	}
+
+	for _, cs := range cases {
+		if cs.got != cs.want {
+			t.Errorf("expected %q, got %q", cs.want, cs.got)
+		}
+	}
+
+	carbon.RegisterLocale("fr", carbon.Locale{
+		Months:   [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		Weekdays: [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+	})
+	if s := c.Locale("fr").IsoFormat("dddd D MMMM YYYY"); s != "mardi 5 mars 2024" {
+		t.Errorf("expected mardi 5 mars 2024, got %s", s)
+	}
+}
+
+func TestBusinessCalendar(t *testing.T) {
+	bc := carbon.NewBusinessCalendar().
+		AddHolidays(carbon.New(time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC), time.UTC)).
+		AddHolidayRules(carbon.AnnualHoliday(time.December, 25), carbon.NthWeekdayHoliday(time.May, -1, time.Monday))
+
+	// Tuesday
+	c := carbon.New(time.Date(2024, 3, 5, 9, 30, 0, 0, time.UTC), time.UTC)
+
+	cases := []struct {
+		got  carbon.Carbon
+		want string
+	}{
+		{bc.AddBusinessDays(c, 5), "2024-03-13 09:30:00"},
+		{bc.AddBusinessDays(c, -2), "2024-03-01 09:30:00"},
+		{bc.SubBusinessDays(c, 1), "2024-03-04 09:30:00"},
+		{bc.NextBusinessDay(c.AddDays(2)), "2024-03-11 09:30:00"},
+		{bc.PreviousBusinessDay(c.AddDays(6)), "2024-03-07 09:30:00"},
+		{bc.NextBusinessDay(carbon.New(time.Date(2025, 12, 24, 0, 0, 0, 0, time.UTC), time.UTC)), "2025-12-26 00:00:00"},
+		{bc.NextBusinessDay(carbon.New(time.Date(2024, 5, 24, 0, 0, 0, 0, time.UTC), time.UTC)), "2024-05-28 00:00:00"},
+	}
+
+	for _, cs := range cases {
+		if s := cs.got.GetDateTimeString(); s != cs.want {
+			t.Errorf("expected %s, got %s", cs.want, s)
+		}
+	}
+
+	if d := bc.DiffInBusinessDays(c, c.AddDays(8)); d != 5 {
+		t.Errorf("expected 5 business days, got %d", d)
+	}
+	if d := bc.DiffInBusinessDays(c.AddDays(8), c); d != -5 {
+		t.Errorf("expected -5 business days, got %d", d)
+	}
+	if bc.IsBusinessDay(c.AddDays(4)) || !bc.IsBusinessDay(c) {
+		t.Error("expected weekend to be skipped")
+	}
+
+	all := carbon.NewBusinessCalendar().SetWeekend(time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday)
+	if n := all.NextBusinessDay(c); n.Valid {
+		t.Errorf("expected invalid carbon without business days, got %s", n)
+	}
+	if n := all.PreviousBusinessDay(c); n.Valid {
+		t.Errorf("expected invalid carbon without business days, got %s", n)
+	}
+	if n := all.AddBusinessDays(c, 3); n.Valid {
+		t.Errorf("expected invalid carbon without business days, got %s", n)
+	}
+}
+
+func TestBusinessCalendarDateFormat(t *testing.T) {
+	bc := carbon.NewBusinessCalendar().AddHolidays(carbon.FromDate(2024, 12, 25, time.UTC))
+
+	tenant := carbon.NewFactory(func(c *carbon.Config) {
+		c.DateFormat = "02/01/2006"
+		c.Timezone = time.UTC
+	})
+	if !bc.IsHoliday(tenant.FromDate(2024, 12, 25)) {
+		t.Error("expected holiday to match regardless of the factory date format")
+	}
+
+	format := carbon.DefaultConfig().DateFormat
+	defer carbon.Configure(func(c *carbon.Config) {
+		c.DateFormat = format
+	})
+	carbon.Configure(func(c *carbon.Config) {
+		c.DateFormat = "Jan 2"
+	})
+	if !bc.IsHoliday(carbon.FromDate(2024, 12, 25, time.UTC)) {
+		t.Error("expected holiday to survive a date format change")
+	}
+	if bc.IsHoliday(carbon.FromDate(2025, 12, 25, time.UTC)) {
+		t.Error("expected static holiday to match its year only")
+	}
+}
+
+func TestBusinessCalendarLoad(t *testing.T) {
+	c := carbon.New(time.Date(2024, 2, 9, 0, 0, 0, 0, time.UTC), time.UTC)
+
+	bc := carbon.NewBusinessCalendar()
+	e := bc.LoadJSON(strings.NewReader(`{"weekend": [5, 6], "holidays": ["2024-02-11"], "annual": ["02-12"]}`))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if n := bc.NextBusinessDay(c); n.GetDateString() != "2024-02-13" {
+		t.Errorf("expected 2024-02-13, got %s", n.GetDateString())
+	}
+
+	bc = carbon.NewBusinessCalendar()
+	if e := bc.LoadJSON(strings.NewReader(`["2024-02-12"]`)); e != nil || bc.IsBusinessDay(c.AddDays(3)) {
+		t.Errorf("expected plain array to load, got %v", e)
+	}
+
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20240212\r\nSUMMARY:Spring\r\n Festival\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20200101\r\nRRULE:FREQ=YEARLY\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20240501\r\nDTEND;VALUE=DATE:20240504\r\nSUMMARY:Labour Day\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	bc = carbon.NewBusinessCalendar()
+	if e := bc.LoadICS(strings.NewReader(ics)); e != nil {
+		t.Fatal(e)
+	}
+	if !bc.IsHoliday(c.AddDays(3)) || !bc.IsHoliday(carbon.New(time.Date(2031, 1, 1, 0, 0, 0, 0, time.UTC), time.UTC)) {
+		t.Error("expected ics holidays to load")
+	}
+	// DTEND is exclusive
+	for day, want := range map[int]bool{1: true, 2: true, 3: true, 4: false} {
+		if got := bc.IsHoliday(carbon.FromDate(2024, 5, day, time.UTC)); got != want {
+			t.Errorf("2024-05-%02d: expected holiday %v, got %v", day, want, got)
+		}
+	}
+}
+
+func TestInterval(t *testing.T) {
+	cases := []struct {
+		in   string
+		want carbon.Interval
+		iso  string
+	}{
+		{"P1Y2M10DT2H30M", carbon.Interval{Years: 1, Months: 2, Days: 10, Hours: 2, Minutes: 30}, "P1Y2M10DT2H30M"},
+		{"P2W", carbon.Interval{Days: 14}, "P14D"},
+		{"-PT1.5S", carbon.Interval{Seconds: -1, Nanos: -500000000}, "-PT1.5S"},
+		{"pt0s", carbon.Interval{}, "PT0S"},
+		{"P1DT0,25S", carbon.Interval{Days: 1, Nanos: 250000000}, "P1DT0.25S"},
+	}
+
+	for _, cs := range cases {
+		i, e := carbon.ParseInterval(cs.in)
+		if e != nil {
+			t.Errorf("%q: %v", cs.in, e)
+			continue
+		}
+		if i != cs.want {
+			t.Errorf("%q: expected %+v, got %+v", cs.in, cs.want, i)
+		}
+		if s := i.String(); s != cs.iso {
+			t.Errorf("%q: expected %s, got %s", cs.in, cs.iso, s)
+		}
+	}
+
+	for _, in := range []string{"", "P", "PT", "P1YT", "1Y", "P1H", "PT1D", "P99999999999999999999Y", "PT99999999999999999999S", "P2000000000000000000W"} {
+		if _, e := carbon.ParseInterval(in); e == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+
+	i := carbon.MustParseInterval("P1Y2M3DT4H")
+	if s := i.ForHumans(); s != "1 year 2 months 3 days 4 hours" {
+		t.Errorf("unexpected %s", s)
+	}
+	if s := i.Invert().ForHumans("zh-CN"); s != "-1年2个月3天4小时" {
+		t.Errorf("unexpected %s", s)
+	}
+
+	c := carbon.New(time.Date(2024, 1, 31, 10, 0, 0, 0, time.UTC), time.UTC)
+	if s := c.AddInterval(i).GetDateTimeString(); s != "2025-04-03 14:00:00" {
+		t.Errorf("expected 2025-04-03 14:00:00, got %s", s)
+	}
+	if s := c.SubInterval(carbon.MustParseInterval("P1M")).GetDateTimeString(); s != "2023-12-31 10:00:00" {
+		t.Errorf("expected 2023-12-31 10:00:00, got %s", s)
+	}
+
+	other := c.AddMonthsNoOverflow(1).AddDays(3).AddHours(5).Add(time.Second)
+	d := c.Diff(other)
+	if s := d.String(); s != "P1M3DT5H1S" {
+		t.Errorf("expected P1M3DT5H1S, got %s", s)
+	}
+	if !c.AddInterval(d).Eq(other) {
+		t.Errorf("expected %s, got %s", other, c.AddInterval(d))
+	}
+	if s := other.Diff(c).String(); s != "-P1M3DT5H1S" {
+		t.Errorf("expected -P1M3DT5H1S, got %s", s)
+	}
+	if !other.AddInterval(other.Diff(c)).Eq(c) {
+		t.Errorf("expected %s, got %s", c, other.AddInterval(other.Diff(c)))
+	}
+
+	start := c.SubDays(16)
+	other = start.AddMonths(1).AddDays(3).AddHours(5).Add(time.Second)
+	if d := start.Diff(other); d.String() != "P1M3DT5H1S" || !start.AddInterval(d).Eq(other) {
+		t.Errorf("expected P1M3DT5H1S to round trip, got %s", d)
+	}
+
+	// a month from the 31st ends on the last day of a shorter month
+	jan31 := carbon.New(time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC), time.UTC)
+	monthEnd := []struct {
+		end    carbon.Carbon
+		months int64
+		diff   string
+		back   string
+	}{
+		{carbon.New(time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC), time.UTC), 1, "P1M", "-P28D"},
+		{carbon.New(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), time.UTC), 1, "P1M1D", "-P1M1D"},
+		{carbon.New(time.Date(2023, 2, 27, 0, 0, 0, 0, time.UTC), time.UTC), 0, "P27D", "-P27D"},
+	}
+	for _, cs := range monthEnd {
+		if m := jan31.DiffInMonths(cs.end); m != cs.months {
+			t.Errorf("%s: expected %d months, got %d", cs.end.GetDateString(), cs.months, m)
+		}
+		if d := jan31.Diff(cs.end).String(); d != cs.diff {
+			t.Errorf("%s: expected %s, got %s", cs.end.GetDateString(), cs.diff, d)
+		}
+		if d := cs.end.Diff(jan31).String(); d != cs.back {
+			t.Errorf("%s: expected %s back, got %s", cs.end.GetDateString(), cs.back, d)
+		}
+		if c := jan31.AddInterval(jan31.Diff(cs.end)); !c.Eq(cs.end) {
+			t.Errorf("%s: expected round trip, got %s", cs.end.GetDateString(), c.GetDateString())
+		}
+		if c := cs.end.AddInterval(cs.end.Diff(jan31)); !c.Eq(jan31) {
+			t.Errorf("%s: expected round trip back, got %s", cs.end.GetDateString(), c.GetDateString())
+		}
+	}
+}
+
+func TestParseISOPeriod(t *testing.T) {
+	cases := []struct {
+		in    string
+		start string
+		end   string
+	}{
+		{"2024-01-01/2024-01-05", "2024-01-01 00:00:00", "2024-01-05 00:00:00"},
+		{"2024-01-01T10:00:00Z/P1DT2H", "2024-01-01 10:00:00", "2024-01-02 12:00:00"},
+		{"P1M/2024-03-15", "2024-02-15 00:00:00", "2024-03-15 00:00:00"},
+	}
+
+	for _, cs := range cases {
+		p, e := carbon.ParseISOPeriod(cs.in, time.UTC)
+		if e != nil {
+			t.Errorf("%q: %v", cs.in, e)
+			continue
+		}
+		if s := p.Start().In(time.UTC).GetDateTimeString(); s != cs.start {
+			t.Errorf("%q: expected start %s, got %s", cs.in, cs.start, s)
+		}
+		if s := p.End().In(time.UTC).GetDateTimeString(); s != cs.end {
+			t.Errorf("%q: expected end %s, got %s", cs.in, cs.end, s)
+		}
+	}
+
+	if p, _ := carbon.ParseISOPeriod("2024-01-01/2024-01-05", time.UTC); len(p.ToSlice()) != 5 {
+		t.Errorf("expected 5 days, got %d", len(p.ToSlice()))
+	}
+	if _, e := carbon.ParseISOPeriod("2024-01-01", time.UTC); e == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestAccessors(t *testing.T) {
+	// Sunday
+	c := carbon.New(time.Date(2024, 3, 10, 14, 7, 9, 5, time.UTC), time.UTC)
+
+	if c.Year() != 2024 || c.Month() != time.March || c.Day() != 10 || c.Hour() != 14 || c.Minute() != 7 || c.Second() != 9 || c.Nanosecond() != 5 {
+		t.Errorf("unexpected getters for %s", c)
+	}
+	if c.DayOfWeek() != time.Sunday || c.DayOfYear() != 70 || c.DaysInMonth() != 31 || !c.IsLeapYear() {
+		t.Errorf("unexpected calendar getters for %s", c)
+	}
+	if y, w := c.ISOWeek(); y != 2024 || w != 10 {
+		t.Errorf("expected ISO week 2024-10, got %d-%d", y, w)
+	}
+	if c.AddYear().IsLeapYear() || c.SetMonth(time.February).DaysInMonth() != 29 {
+		t.Error("unexpected leap year")
+	}
+
+	// Jan 1 2024 is a Monday
+	if w := c.WeekOfYear(); w != 10 {
+		t.Errorf("expected week 10 with weeks starting on Monday, got %d", w)
+	}
+	old := carbon.WeekStartDay
+	carbon.WeekStartDay = time.Sunday
+	if w := c.WeekOfYear(); w != 11 {
+		t.Errorf("expected week 11 with weeks starting on Sunday, got %d", w)
+	}
+	carbon.WeekStartDay = old
+
+	cases := []struct {
+		got  carbon.Carbon
+		want string
+	}{
+		{c.SetYear(2025), "2025-03-10 14:07:09"},
+		{c.SetMonth(time.December), "2024-12-10 14:07:09"},
+		{c.SetDay(31), "2024-03-31 14:07:09"},
+		{c.SetDate(2023, time.February, 29), "2023-03-01 14:07:09"},
+		{c.SetTime(8, 30, 0), "2024-03-10 08:30:00"},
+		{c.SetHour(23).SetMinute(59).SetSecond(58), "2024-03-10 23:59:58"},
+		{c.SetDay(31).SetMonth(time.April), "2024-05-01 14:07:09"},
+	}
+
+	for _, cs := range cases {
+		if s := cs.got.GetDateTimeString(); s != cs.want {
+			t.Errorf("expected %s, got %s", cs.want, s)
+		}
+	}
+}
+
+func TestWeekdayNavigation(t *testing.T) {
+	// Tuesday
+	c := carbon.New(time.Date(2024, 5, 14, 10, 0, 0, 0, time.UTC), time.UTC)
+
+	cases := []struct {
+		got  carbon.Carbon
+		want string
+	}{
+		{c.Next(time.Friday), "2024-05-17 00:00:00"},
+		{c.Next(time.Tuesday), "2024-05-21 00:00:00"},
+		{c.Previous(time.Monday), "2024-05-13 00:00:00"},
+		{c.Previous(time.Tuesday), "2024-05-07 00:00:00"},
+		{c.FirstOfMonth(time.Monday), "2024-05-06 00:00:00"},
+		{c.FirstOfMonth(time.Wednesday), "2024-05-01 00:00:00"},
+		{c.LastOfMonth(time.Friday), "2024-05-31 00:00:00"},
+		{c.LastOfMonth(time.Monday), "2024-05-27 00:00:00"},
+		{c.FirstOfQuarter(time.Monday), "2024-04-01 00:00:00"},
+		{c.LastOfQuarter(time.Sunday), "2024-06-30 00:00:00"},
+		{c.FirstOfYear(time.Sunday), "2024-01-07 00:00:00"},
+		{c.LastOfYear(time.Friday), "2024-12-27 00:00:00"},
+	}
+
+	for _, cs := range cases {
+		if s := cs.got.GetDateTimeString(); s != cs.want {
+			t.Errorf("expected %s, got %s", cs.want, s)
+		}
+	}
+
+	nth := func(c carbon.Carbon, ok bool) string {
+		if !ok {
+			return ""
+		}
+		return c.GetDateString()
+	}
+	nthCases := []struct {
+		got  string
+		want string
+	}{
+		{nth(c.NthOfMonth(2, time.Thursday)), "2024-05-09"},
+		{nth(c.NthOfMonth(5, time.Friday)), "2024-05-31"},
+		{nth(c.NthOfMonth(5, time.Monday)), ""},
+		{nth(c.NthOfQuarter(13, time.Tuesday)), "2024-06-25"},
+		{nth(c.NthOfYear(53, time.Monday)), "2024-12-30"},
+		{nth(c.NthOfYear(53, time.Wednesday)), ""},
+		{nth(c.NthOfMonth(0, time.Monday)), ""},
+	}
+
+	for i, cs := range nthCases {
+		if cs.got != cs.want {
+			t.Errorf("case %d: expected %q, got %q", i, cs.want, cs.got)
+		}
+	}
+}
+
+func TestFactory(t *testing.T) {
+	shanghai := time.FixedZone("CST", 8*3600)
+	tenantA := carbon.NewFactory(func(c *carbon.Config) {
+		c.DateTimeFormat = "2006/01/02 15:04"
+		c.Timezone = shanghai
+		c.WeekStartDay = time.Sunday
+		c.JSONFormat = carbon.FormatDateTime
+		c.Locale = "zh-CN"
+	})
+	// unset settings keep the package defaults, Monday week start and object JSON
+	tenantB := carbon.NewFactory(func(c *carbon.Config) {
+		c.Timezone = time.UTC
+	})
+
+	instant := time.Date(2024, 5, 15, 20, 30, 0, 0, time.UTC)
+	a, b := tenantA.New(instant), tenantB.New(instant)
+
+	cases := []struct {
+		got  string
+		want string
+	}{
+		{a.Format(), "2024/05/16 04:30"},
+		{b.Format(), "2024-05-15 20:30:00"},
+		{a.StartOfWeek().GetDateString(), "2024-05-12"},
+		{b.StartOfWeek().GetDateString(), "2024-05-13"},
+		{a.AddDays(3).Format(), "2024/05/19 04:30"},
+		{a.LocaleName(), "zh-CN"},
+		{b.LocaleName(), "en"},
+	}
+	for i, cs := range cases {
+		if cs.got != cs.want {
+			t.Errorf("case %d: expected %q, got %q", i, cs.want, cs.got)
+		}
+	}
+
+	if js, _ := json.Marshal(a); string(js) != `"2024/05/16 04:30"` {
+		t.Errorf("expected tenant json format, got %s", js)
+	}
+	if js, _ := json.Marshal(b); !strings.HasPrefix(string(js), "{") {
+		t.Errorf("expected default object json format, got %s", js)
+	}
+	if cfg := tenantB.Config(); cfg.WeekStartDay != carbon.WeekStartDay || cfg.JSONFormat != carbon.DefaultJSONFormat {
+		t.Errorf("expected unset settings to keep package defaults, got %+v", cfg)
+	}
+
+	p, e := tenantA.Parse("2024/05/16 04:30", nil)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !p.GetTime().Equal(instant) || p.Timezone() != shanghai {
+		t.Errorf("expected %s in tenant timezone, got %s", instant, p)
+	}
+	if _, e := tenantB.Parse("2024/05/16 04:30", nil); e == nil {
+		t.Error("expected layouts of other factories not to be used")
+	}
+	tenantB.AddParseLayouts("2006/01/02 15:04")
+	if _, e := tenantB.Parse("2024/05/16 04:30", nil); e != nil {
+		t.Error(e)
+	}
+	if _, e := carbon.Parse("2024/05/16 04:30", nil); e == nil {
+		t.Error("expected factory layouts not to leak into the default factory")
+	}
+
+	// the default factory reflects the package variables
+	if carbon.DefaultFactory().Config().DateTimeFormat != carbon.DefaultDateTimeFormat {
+		t.Error("expected default factory to use package defaults")
+	}
+	if c := carbon.New(instant); c.Format() != instant.In(carbon.Timezone).Format(carbon.DefaultDateTimeFormat) {
+		t.Errorf("unexpected default format %s", c.Format())
+	}
+}
+
+func TestConfigureConcurrent(t *testing.T) {
+	layouts := carbon.DefaultConfig().ParseLayouts
+	defer carbon.Configure(func(c *carbon.Config) {
+		c.ParseLayouts = layouts
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			carbon.AddParseLoyouts("02.01.2006")
+		}()
+		go func() {
+			defer wg.Done()
+			if _, e := carbon.Parse("2024-05-15 10:00:00", nil); e != nil {
+				t.Error(e)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if c, e := carbon.Parse("15.05.2024", time.UTC); e != nil || c.GetDateString() != "2024-05-15" {
+		t.Errorf("expected added layout to parse, got %s, %v", c, e)
+	}
+}
+
+func TestFromTimestamp(t *testing.T) {
+	instant := time.Date(2024, 5, 15, 10, 30, 45, 123456789, time.UTC)
+
+	cases := []struct {
+		got  carbon.Carbon
+		want time.Time
+	}{
+		{carbon.FromTimestamp(instant.Unix(), time.UTC), instant.Truncate(time.Second)},
+		{carbon.FromTimestampMilli(instant.UnixMilli(), time.UTC), instant.Truncate(time.Millisecond)},
+		{carbon.FromTimestampMicro(instant.UnixMicro(), time.UTC), instant.Truncate(time.Microsecond)},
+		{carbon.FromTimestampNano(instant.UnixNano(), time.UTC), instant},
+		{carbon.FromDate(2024, time.May, 15, time.UTC), time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)},
+		{carbon.FromDate(2024, time.February, 30, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{carbon.FromDateTime(2024, time.May, 15, 10, 30, 45, time.UTC), instant.Truncate(time.Second)},
+	}
+	for i, cs := range cases {
+		if !cs.got.Valid || !cs.got.GetTime().Equal(cs.want) {
+			t.Errorf("case %d: expected %s, got %s", i, cs.want, cs.got)
+		}
+	}
+
+	c := carbon.New(instant)
+	if c.TimestampMilli() != instant.UnixMilli() || c.TimestampMicro() != instant.UnixMicro() || c.TimestampNano() != instant.UnixNano() {
+		t.Errorf("unexpected timestamps %d %d %d", c.TimestampMilli(), c.TimestampMicro(), c.TimestampNano())
+	}
+
+	shanghai := time.FixedZone("CST", 8*3600)
+	if d := carbon.FromDate(2024, time.May, 15, shanghai); d.GetDateTimeString() != "2024-05-15 00:00:00" || d.Timezone() != shanghai {
+		t.Errorf("expected date in given timezone, got %s", d)
+	}
+
+	carbon.SetTestNow(carbon.New(instant))
+	defer carbon.ResetClock()
+	if ft := carbon.FromTime(8, 0, 0, shanghai); ft.GetDateTimeString() != "2024-05-15 08:00:00" {
+		t.Errorf("expected today at 08:00, got %s", ft)
+	}
+
+	for _, cs := range []struct {
+		src  int64
+		want time.Time
+	}{
+		{instant.Unix(), instant.Truncate(time.Second)},
+		{instant.UnixMilli(), instant.Truncate(time.Millisecond)},
+	} {
+		var sc carbon.Carbon
+		if e := sc.Scan(cs.src); e != nil || !sc.Valid || !sc.GetTime().Equal(cs.want) {
+			t.Errorf("scan %d: expected %s, got %s, %v", cs.src, cs.want, sc, e)
+		}
+	}
+}
+
+func TestRoundTo(t *testing.T) {
+	c := carbon.New(time.Date(2024, 5, 15, 10, 37, 29, 500, time.UTC), time.UTC)
+
+	cases := []struct {
+		got  carbon.Carbon
+		want string
+	}{
+		{c.FloorTo(carbon.Minute, 5), "2024-05-15 10:35:00"},
+		{c.CeilTo(carbon.Minute, 5), "2024-05-15 10:40:00"},
+		{c.RoundTo(carbon.Minute, 5), "2024-05-15 10:35:00"},
+		{c.RoundTo(carbon.Minute, 15), "2024-05-15 10:30:00"},
+		{c.FloorTo(carbon.Hour, 6), "2024-05-15 06:00:00"},
+		{c.CeilTo(carbon.Hour, 6), "2024-05-15 12:00:00"},
+		{c.RoundTo(carbon.Second, 30), "2024-05-15 10:37:30"},
+		{c.FloorTo(carbon.Second, 1), "2024-05-15 10:37:29"},
+		{c.CeilTo(carbon.Hour, 7), "2024-05-15 14:00:00"},
+		{c.AddHours(12).CeilTo(carbon.Hour, 7), "2024-05-16 00:00:00"},
+		{c.FloorTo(carbon.Day, 1), "2024-05-15 00:00:00"},
+		{c.RoundTo(carbon.Day, 1), "2024-05-15 00:00:00"},
+		{c.FloorTo(carbon.Day, 10), "2024-05-11 00:00:00"},
+		{c.AddDays(15).CeilTo(carbon.Day, 10), "2024-05-31 00:00:00"},
+		{c.AddDays(16).CeilTo(carbon.Day, 10), "2024-06-01 00:00:00"},
+		{c.FloorTo(carbon.Week, 1), "2024-05-13 00:00:00"},
+		{c.CeilTo(carbon.Week, 1), "2024-05-20 00:00:00"},
+		{c.FloorTo(carbon.Week, 2), "2024-05-06 00:00:00"},
+		{c.AddWeek().FloorTo(carbon.Week, 2), "2024-05-20 00:00:00"},
+		{c.FloorTo(carbon.Month, 3), "2024-04-01 00:00:00"},
+		{c.RoundTo(carbon.Month, 3), "2024-04-01 00:00:00"},
+		{c.AddMonth().RoundTo(carbon.Month, 3), "2024-07-01 00:00:00"},
+		{c.CeilTo(carbon.Month, 5), "2024-06-01 00:00:00"},
+		{c.AddMonths(6).CeilTo(carbon.Month, 5), "2025-01-01 00:00:00"},
+		{c.FloorTo(carbon.Year, 10), "2020-01-01 00:00:00"},
+		{c.RoundTo(carbon.Year, 1), "2024-01-01 00:00:00"},
+		{c.CeilTo(carbon.Year, 1), "2025-01-01 00:00:00"},
+		{c.FloorTo(carbon.Minute, 0), "2024-05-15 10:37:00"},
+	}
+	for i, cs := range cases {
+		if s := cs.got.GetDateTimeString(); s != cs.want {
+			t.Errorf("case %d: expected %s, got %s", i, cs.want, s)
+		}
+	}
+
+	boundary := carbon.New(time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC), time.UTC)
+	if got := boundary.CeilTo(carbon.Minute, 15); !got.Eq(boundary) {
+		t.Errorf("expected boundary to be kept, got %s", got)
+	}
+
+	// the week of 2024-12-30 is the first week of 2025
+	if got := carbon.New(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), time.UTC).FloorTo(carbon.Week, 2); got.GetDateString() != "2024-12-30" {
+		t.Errorf("expected 2024-12-30, got %s", got)
+	}
+
+	func() {
+		weekStart := carbon.DefaultConfig().WeekStartDay
+		defer carbon.Configure(func(c *carbon.Config) {
+			c.WeekStartDay = weekStart
+		})
+		carbon.Configure(func(c *carbon.Config) {
+			c.WeekStartDay = time.Sunday
+		})
+		if got := c.FloorTo(carbon.Week, 1); got.GetDateString() != "2024-05-12" {
+			t.Errorf("expected week to start on Sunday, got %s", got)
+		}
+	}()
+
+	kolkata := time.FixedZone("IST", 5*3600+1800)
+	k := carbon.New(time.Date(2024, 5, 15, 10, 37, 0, 0, kolkata), kolkata)
+	if got := k.StartOfHour().GetDateTimeString(); got != "2024-05-15 10:00:00" {
+		t.Errorf("expected wall clock hour, got %s", got)
+	}
+	if got := k.FloorTo(carbon.Hour, 6).GetDateTimeString(); got != "2024-05-15 06:00:00" {
+		t.Errorf("expected wall clock bucket, got %s", got)
+	}
+
+	berlin, e := time.LoadLocation("Europe/Berlin")
+	if e != nil {
+		t.Skip(e)
+	}
+	// 03:30 CEST right after the gap, 00:00 is still CET
+	gap := carbon.New(time.Date(2024, 3, 31, 3, 30, 0, 0, berlin), berlin)
+	if got := gap.FloorTo(carbon.Hour, 6).GetTime().Format("15:04 MST"); got != "00:00 CET" {
+		t.Errorf("expected 00:00 CET, got %s", got)
+	}
+	// second 02:30 of the overlap stays in CET
+	overlap := carbon.New(time.Date(2024, 10, 27, 1, 40, 0, 0, time.UTC), berlin)
+	if got := overlap.FloorTo(carbon.Minute, 15).GetTime().Format("15:04 MST"); got != "02:30 CET" {
+		t.Errorf("expected 02:30 CET, got %s", got)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	berlin, e := time.LoadLocation("Europe/Berlin")
+	if e != nil {
+		t.Skip(e)
+	}
+	c := carbon.New(time.Date(2024, 5, 15, 10, 30, 45, 123456789, berlin), berlin).Locale("zh-CN")
+	// carries a monotonic reading
+	now := carbon.New(time.Now(), berlin)
+	custom := carbon.New(time.Date(2024, 5, 15, 10, 30, 0, 0, time.FixedZone("XYZ", 5*3600+1800)))
+
+	t.Run("text", func(t *testing.T) {
+		data, e := c.MarshalText()
+		if e != nil || string(data) != "2024-05-15T10:30:45.123456789+02:00" {
+			t.Fatalf("unexpected text %s, %v", data, e)
+		}
+		var got carbon.Carbon
+		if e := got.UnmarshalText(data); e != nil || !got.Eq(c) {
+			t.Errorf("expected %s, got %s, %v", c, got, e)
+		}
+		if e := got.UnmarshalText([]byte("2024-05-15 10:30:45")); e != nil || !got.Valid {
+			t.Errorf("expected parse layouts to be accepted, %v", e)
+		}
+		if data, _ := (carbon.Carbon{}).MarshalText(); len(data) != 0 {
+			t.Errorf("expected empty text for invalid carbon, got %s", data)
+		}
+		if e := got.UnmarshalText(nil); e != nil || got.Valid {
+			t.Error("expected invalid carbon for empty text")
+		}
+	})
+
+	t.Run("binary", func(t *testing.T) {
+		for _, want := range []carbon.Carbon{c, now, custom, {}} {
+			data, e := want.MarshalBinary()
+			if e != nil {
+				t.Fatal(e)
+			}
+			var got carbon.Carbon
+			if e := got.UnmarshalBinary(data); e != nil {
+				t.Fatal(e)
+			}
+			assertSameCarbon(t, want, got)
+		}
+		var got carbon.Carbon
+		if e := got.UnmarshalBinary([]byte{1, 1, 200}); e == nil {
+			t.Error("expected error for truncated data")
+		}
+	})
+
+	t.Run("gob", func(t *testing.T) {
+		type entry struct {
+			Key       string
+			CreatedAt carbon.Carbon
+			DeletedAt carbon.Carbon
+		}
+		var buf bytes.Buffer
+		if e := gob.NewEncoder(&buf).Encode(entry{"k", c, carbon.Carbon{}}); e != nil {
+			t.Fatal(e)
+		}
+		var got entry
+		if e := gob.NewDecoder(&buf).Decode(&got); e != nil {
+			t.Fatal(e)
+		}
+		assertSameCarbon(t, c, got.CreatedAt)
+		assertSameCarbon(t, carbon.Carbon{}, got.DeletedAt)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		type config struct {
+			Start carbon.Carbon `yaml:"start"`
+			End   carbon.Carbon `yaml:"end"`
+		}
+		data, e := yaml.Marshal(config{Start: c})
+		if e != nil {
+			t.Fatal(e)
+		}
+		if want := "start: \"2024-05-15T10:30:45.123456789+02:00\"\nend: null\n"; string(data) != want {
+			t.Errorf("expected %q, got %q", want, data)
+		}
+		var got config
+		if e := yaml.Unmarshal(data, &got); e != nil {
+			t.Fatal(e)
+		}
+		if !got.Start.Eq(c) || got.End.Valid {
+			t.Errorf("unexpected round trip %+v", got)
+		}
+
+		cases := []struct {
+			src  string
+			want time.Time
+		}{
+			{"start: 2024-05-15T10:30:00Z", time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)},
+			{"start: 1715769000", time.Date(2024, 5, 15, 10, 30, 0, 0, time.UTC)},
+			{"start: '2024-05-15 10:30:00'", time.Date(2024, 5, 15, 10, 30, 0, 0, carbon.Timezone)},
+		}
+		for _, cs := range cases {
+			var got config
+			if e := yaml.Unmarshal([]byte(cs.src), &got); e != nil || !got.Start.GetTime().Equal(cs.want) {
+				t.Errorf("%s: expected %s, got %s, %v", cs.src, cs.want, got.Start, e)
+			}
+		}
+		if e := yaml.Unmarshal([]byte("start: [1]"), &got); e == nil {
+			t.Error("expected error for sequence")
+		}
+	})
+}
+
+func assertSameCarbon(t *testing.T, want, got carbon.Carbon) {
+	t.Helper()
+	if want.Valid != got.Valid {
+		t.Fatalf("expected valid %v, got %v", want.Valid, got.Valid)
+	}
+	if !want.Valid {
+		return
+	}
+	if !got.GetTime().Equal(want.GetTime()) || got.GetTime() != got.GetTime().Round(0) {
+		t.Errorf("expected monotonic-free %s, got %s", want, got)
+	}
+	if got.Timezone().String() != want.Timezone().String() || got.Format(time.RFC3339) != want.Format(time.RFC3339) {
+		t.Errorf("expected location %s, got %s", want.Format(time.RFC3339+" MST"), got.Format(time.RFC3339+" MST"))
+	}
+	if got.LocaleName() != want.LocaleName() {
+		t.Errorf("expected locale %s, got %s", want.LocaleName(), got.LocaleName())
+	}
+}
+
+func TestAge(t *testing.T) {
+	born := carbon.New(time.Date(1990, 5, 15, 23, 30, 0, 0, time.UTC), time.UTC)
+	leap := carbon.New(time.Date(2000, 2, 29, 8, 0, 0, 0, time.UTC), time.UTC)
+	at := func(y int, m time.Month, d int) carbon.Carbon {
+		return carbon.New(time.Date(y, m, d, 0, 0, 0, 0, time.UTC), time.UTC)
+	}
+
+	ages := []struct {
+		got  int
+		want int
+	}{
+		{born.AgeAt(at(2024, 5, 14)), 33},
+		{born.AgeAt(at(2024, 5, 15)), 34},
+		{born.AgeAt(at(1990, 5, 16)), 0},
+		{born.AgeAt(at(1980, 1, 1)), 0},
+		{leap.AgeAt(at(2001, 2, 28)), 0},
+		{leap.AgeAt(at(2001, 3, 1)), 1},
+		{leap.AgeAt(at(2004, 2, 28)), 3},
+		{leap.AgeAt(at(2004, 2, 29)), 4},
+	}
+	for i, cs := range ages {
+		if cs.got != cs.want {
+			t.Errorf("case %d: expected %d, got %d", i, cs.want, cs.got)
+		}
+	}
+
+	birthdays := []struct {
+		got  bool
+		want bool
+	}{
+		{born.IsBirthday(at(2024, 5, 15).AddHours(23)), true},
+		{born.IsBirthday(at(2024, 5, 16)), false},
+		{leap.IsBirthday(at(2023, 3, 1)), true},
+		{leap.IsBirthday(at(2023, 2, 28)), false},
+		{leap.IsBirthday(at(2024, 2, 29)), true},
+		{leap.IsBirthday(at(2024, 3, 1)), false},
+	}
+	for i, cs := range birthdays {
+		if cs.got != cs.want {
+			t.Errorf("case %d: expected %v, got %v", i, cs.want, cs.got)
+		}
+	}
+
+	defer carbon.ResetClock()
+	carbon.SetTestNow(at(2023, 5, 15).AddHours(12))
+	if born.Age() != 33 || !born.IsBirthday() {
+		t.Errorf("expected 33rd birthday, got %d", born.Age())
+	}
+
+	next := []struct {
+		now  carbon.Carbon
+		c    carbon.Carbon
+		want string
+	}{
+		{at(2023, 5, 15).AddHours(12), born, "2023-05-15 00:00:00"},
+		{at(2023, 5, 16), born, "2024-05-15 00:00:00"},
+		{at(2023, 1, 1), leap, "2023-03-01 00:00:00"},
+		{at(2023, 3, 2), leap, "2024-02-29 00:00:00"},
+	}
+	for i, cs := range next {
+		carbon.SetTestNow(cs.now)
+		if got := cs.c.NextAnniversary().GetDateTimeString(); got != cs.want {
+			t.Errorf("case %d: expected %s, got %s", i, cs.want, got)
+		}
+	}
+}
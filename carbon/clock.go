@@ -0,0 +1,125 @@
+package carbon
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock source of the current time, every "now" function of the package reads it
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapter to use a function as Clock
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+type offsetClock struct {
+	base   Clock
+	offset time.Duration
+}
+
+func (c offsetClock) Now() time.Time {
+	return c.base.Now().Add(c.offset)
+}
+
+type clockCtxKey struct{}
+
+var (
+	clock   Clock = systemClock{}
+	clockMu sync.RWMutex
+)
+
+// SetClock replace the package clock, nil restores the system clock
+func SetClock(c Clock) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	if c == nil {
+		c = systemClock{}
+	}
+	clock = c
+}
+
+func GetClock() Clock {
+	clockMu.RLock()
+	defer clockMu.RUnlock()
+	return clock
+}
+
+// SetTestNow fix the current time to c, an invalid carbon restores the system clock
+func SetTestNow(c Carbon) {
+	if !c.Valid {
+		SetClock(nil)
+		return
+	}
+	SetClock(fixedClock(c.t))
+}
+
+// ResetClock restore the system clock
+func ResetClock() {
+	SetClock(nil)
+}
+
+// Freeze stop the clock at the current time and return it
+func Freeze() Carbon {
+	now := Now()
+	SetTestNow(now)
+	return now
+}
+
+// Travel move the clock by d, a frozen clock stays frozen
+func Travel(d time.Duration) {
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	if fixed, ok := clock.(fixedClock); ok {
+		clock = fixedClock(time.Time(fixed).Add(d))
+		return
+	}
+	if oc, ok := clock.(offsetClock); ok {
+		oc.offset += d
+		clock = oc
+		return
+	}
+	clock = offsetClock{base: clock, offset: d}
+}
+
+// WithClock scope a clock to ctx, only NowContext reads it. Today, Tomorrow, GetTimestamp,
+// FormatNow, ParseRelative with a zero base, Age and the other functions without a ctx
+// read the package clock, so parallel tests that change it still share one clock
+func WithClock(ctx context.Context, c Clock) context.Context {
+	return context.WithValue(ctx, clockCtxKey{}, c)
+}
+
+// ClockFromContext get the clock scoped to ctx, falls back to the package clock
+func ClockFromContext(ctx context.Context) Clock {
+	if c, ok := ctx.Value(clockCtxKey{}).(Clock); ok && c != nil {
+		return c
+	}
+
+	return GetClock()
+}
+
+// NowContext like Now, reading the clock scoped to ctx
+func NowContext(ctx context.Context, tz ...*time.Location) Carbon {
+	return defaultFactory.New(ClockFromContext(ctx).Now(), tz...)
+}
+
+func clockNow() time.Time {
+	return GetClock().Now()
+}
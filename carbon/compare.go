@@ -0,0 +1,144 @@
+package carbon
+
+import "time"
+
+//////////////////////////
+// Comparison
+/////////////////////////
+
+func (c Carbon) Eq(other Carbon) bool {
+	return c.t.Equal(other.t)
+}
+
+func (c Carbon) Ne(other Carbon) bool {
+	return !c.Eq(other)
+}
+
+func (c Carbon) Gt(other Carbon) bool {
+	return c.t.After(other.t)
+}
+
+func (c Carbon) Gte(other Carbon) bool {
+	return !c.t.Before(other.t)
+}
+
+func (c Carbon) Lt(other Carbon) bool {
+	return c.t.Before(other.t)
+}
+
+func (c Carbon) Lte(other Carbon) bool {
+	return !c.t.After(other.t)
+}
+
+// Between check if carbon is between a and b, in either order
+func (c Carbon) Between(a, b Carbon, inclusive bool) bool {
+	if a.Gt(b) {
+		a, b = b, a
+	}
+	if inclusive {
+		return c.Gte(a) && c.Lte(b)
+	}
+
+	return c.Gt(a) && c.Lt(b)
+}
+
+// IsSameDay check if other is the same calendar day, in carbon's timezone
+func (c Carbon) IsSameDay(other Carbon) bool {
+	y1, m1, d1 := c.t.Date()
+	y2, m2, d2 := other.t.In(c.t.Location()).Date()
+
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
+
+func (c Carbon) IsSameMonth(other Carbon) bool {
+	y1, m1, _ := c.t.Date()
+	y2, m2, _ := other.t.In(c.t.Location()).Date()
+
+	return y1 == y2 && m1 == m2
+}
+
+func (c Carbon) IsSameYear(other Carbon) bool {
+	return c.t.Year() == other.t.In(c.t.Location()).Year()
+}
+
+// Min get the earliest of carbon and others
+func (c Carbon) Min(others ...Carbon) Carbon {
+	for _, o := range others {
+		if o.Lt(c) {
+			c = o
+		}
+	}
+
+	return c
+}
+
+// Max get the latest of carbon and others
+func (c Carbon) Max(others ...Carbon) Carbon {
+	for _, o := range others {
+		if o.Gt(c) {
+			c = o
+		}
+	}
+
+	return c
+}
+
+//////////////////////////
+// Difference
+/////////////////////////
+
+// DiffInSeconds whole seconds from carbon to other, negative if other is before carbon,
+// pass absolute to always get a positive value
+func (c Carbon) DiffInSeconds(other Carbon, absolute ...bool) int64 {
+	return c.diffDuration(other, time.Second, absolute)
+}
+
+func (c Carbon) DiffInMinutes(other Carbon, absolute ...bool) int64 {
+	return c.diffDuration(other, time.Minute, absolute)
+}
+
+func (c Carbon) DiffInHours(other Carbon, absolute ...bool) int64 {
+	return c.diffDuration(other, time.Hour, absolute)
+}
+
+// DiffInDays whole calendar days from carbon to other, DST transitions count as one day
+func (c Carbon) DiffInDays(other Carbon, absolute ...bool) int64 {
+	return c.diffCalendar(other, absolute, func(start, end time.Time) int {
+		return daysBetween(start, end)
+	})
+}
+
+func (c Carbon) DiffInWeeks(other Carbon, absolute ...bool) int64 {
+	return c.DiffInDays(other, absolute...) / 7
+}
+
+// DiffInMonths whole calendar months from carbon to other, a month from the 31st ends on the last day
+// of a shorter month, so Jan 31 to Feb 28 2023 is 1 month and to Feb 27 is 0
+func (c Carbon) DiffInMonths(other Carbon, absolute ...bool) int64 {
+	return c.diffCalendar(other, absolute, monthsBetween)
+}
+
+func (c Carbon) DiffInYears(other Carbon, absolute ...bool) int64 {
+	return c.DiffInMonths(other, absolute...) / 12
+}
+
+func (c Carbon) diffDuration(other Carbon, unit time.Duration, absolute []bool) int64 {
+	d := int64(other.t.Sub(c.t) / unit)
+	if len(absolute) > 0 && absolute[0] && d < 0 {
+		return -d
+	}
+
+	return d
+}
+
+func (c Carbon) diffCalendar(other Carbon, absolute []bool, between func(start, end time.Time) int) int64 {
+	if other.t.Before(c.t) {
+		d := int64(between(other.t.In(c.t.Location()), c.t))
+		if len(absolute) > 0 && absolute[0] {
+			return d
+		}
+		return -d
+	}
+
+	return int64(between(c.t, other.t))
+}
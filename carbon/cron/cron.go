@@ -0,0 +1,358 @@
+// Package cron computes fire times of cron expressions as carbon values.
+//
+// Expressions have 5 fields (minute hour day-of-month month day-of-week)
+// or 6 fields with a leading seconds field, and support "*", "?", lists,
+// ranges, steps ("*/5", "10-40/10"), month and weekday names and the
+// macros @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly.
+// When both day-of-month and day-of-week are restricted a day matching either fires.
+//
+// Fire times are matched against the wall clock of the given carbon's timezone.
+// Across DST transitions the behaviour is deterministic:
+// wall times skipped by a gap fire at the first instant after the gap, once even if
+// several of them match, and wall times repeated by an overlap fire at their earliest
+// occurrence after (Next) or before (Prev) the reference.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enorith/supports/carbon"
+)
+
+// searchYears give up searching for a fire time after this many years,
+// Feb 29 schedules may skip up to 8 years around 2100
+const searchYears = 10
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	secondField = field{name: "second", min: 0, max: 59}
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day-of-month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// day-of-week accepts 7 as Sunday
+	dowField = field{name: "day-of-week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	macros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// Schedule parsed cron expression
+type Schedule struct {
+	expr                             string
+	second, minute, hour, dom, month uint64
+	dow                              uint64
+	domRestricted, dowRestricted     bool
+}
+
+// Parse parse a 5 or 6 field cron expression or macro
+func Parse(expr string) (*Schedule, error) {
+	spec := strings.TrimSpace(expr)
+	if m, ok := macros[strings.ToLower(spec)]; ok {
+		spec = m
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron: expected 5 or 6 fields in %q, got %d", expr, len(fields))
+	}
+
+	s := &Schedule{expr: expr}
+	var e error
+	if s.second, e = parseField(fields[0], secondField); e != nil {
+		return nil, e
+	}
+	if s.minute, e = parseField(fields[1], minuteField); e != nil {
+		return nil, e
+	}
+	if s.hour, e = parseField(fields[2], hourField); e != nil {
+		return nil, e
+	}
+	if s.dom, e = parseField(fields[3], domField); e != nil {
+		return nil, e
+	}
+	if s.month, e = parseField(fields[4], monthField); e != nil {
+		return nil, e
+	}
+	if s.dow, e = parseField(fields[5], dowField); e != nil {
+		return nil, e
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domRestricted = !isWildcard(fields[3])
+	s.dowRestricted = !isWildcard(fields[5])
+
+	return s, nil
+}
+
+// MustParse like Parse but panics on error
+func MustParse(expr string) *Schedule {
+	s, e := Parse(expr)
+	if e != nil {
+		panic(e)
+	}
+
+	return s
+}
+
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next first fire time strictly after after, in its timezone,
+// an invalid carbon is returned if the schedule never fires
+func (s *Schedule) Next(after carbon.Carbon) carbon.Carbon {
+	ref := after.GetTime()
+	loc := ref.Location()
+	w := wall(ref).Truncate(time.Second).Add(time.Second)
+	limit := w.AddDate(searchYears, 0, 0)
+
+	for w.Before(limit) {
+		w = s.nextWall(w, limit)
+		if w.IsZero() {
+			break
+		}
+		for _, t := range instants(w, loc) {
+			if t.After(ref) {
+				return after.Add(t.Sub(ref))
+			}
+		}
+		w = w.Add(time.Second)
+	}
+
+	return carbon.Carbon{}
+}
+
+// Prev last fire time strictly before before, in its timezone,
+// an invalid carbon is returned if the schedule never fires
+func (s *Schedule) Prev(before carbon.Carbon) carbon.Carbon {
+	ref := before.GetTime()
+	loc := ref.Location()
+	w := wall(ref)
+	if t := w.Truncate(time.Second); t.Equal(w) {
+		w = t.Add(-time.Second)
+	} else {
+		w = t
+	}
+	limit := w.AddDate(-searchYears, 0, 0)
+
+	for w.After(limit) {
+		w = s.prevWall(w, limit)
+		if w.IsZero() {
+			break
+		}
+		for _, t := range instants(w, loc) {
+			if t.Before(ref) {
+				return before.Add(t.Sub(ref))
+			}
+		}
+		w = w.Add(-time.Second)
+	}
+
+	return carbon.Carbon{}
+}
+
+// Iter iterate fire times after from, compatible with go 1.23 range-over-func (iter.Seq[carbon.Carbon])
+func (s *Schedule) Iter(from carbon.Carbon) func(yield func(carbon.Carbon) bool) {
+	return func(yield func(carbon.Carbon) bool) {
+		for c := s.Next(from); c.Valid; c = s.Next(c) {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// nextWall first matching wall time at or after w, zero time if none before limit
+func (s *Schedule) nextWall(w, limit time.Time) time.Time {
+	for w.Before(limit) {
+		switch {
+		case !has(s.month, int(w.Month())):
+			w = time.Date(w.Year(), w.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+		case !s.matchDay(w):
+			w = time.Date(w.Year(), w.Month(), w.Day()+1, 0, 0, 0, 0, time.UTC)
+		case !has(s.hour, w.Hour()):
+			w = w.Truncate(time.Hour).Add(time.Hour)
+		case !has(s.minute, w.Minute()):
+			w = w.Truncate(time.Minute).Add(time.Minute)
+		case !has(s.second, w.Second()):
+			w = w.Add(time.Second)
+		default:
+			return w
+		}
+	}
+
+	return time.Time{}
+}
+
+// prevWall last matching wall time at or before w, zero time if none after limit
+func (s *Schedule) prevWall(w, limit time.Time) time.Time {
+	for w.After(limit) {
+		switch {
+		case !has(s.month, int(w.Month())):
+			w = time.Date(w.Year(), w.Month(), 1, 0, 0, 0, 0, time.UTC).Add(-time.Second)
+		case !s.matchDay(w):
+			w = time.Date(w.Year(), w.Month(), w.Day(), 0, 0, 0, 0, time.UTC).Add(-time.Second)
+		case !has(s.hour, w.Hour()):
+			w = w.Truncate(time.Hour).Add(-time.Second)
+		case !has(s.minute, w.Minute()):
+			w = w.Truncate(time.Minute).Add(-time.Second)
+		case !has(s.second, w.Second()):
+			w = w.Add(-time.Second)
+		default:
+			return w
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(w time.Time) bool {
+	dom := has(s.dom, w.Day())
+	dow := has(s.dow, int(w.Weekday()))
+	if s.domRestricted && s.dowRestricted {
+		return dom || dow
+	}
+
+	return dom && dow
+}
+
+// wall the wall clock of t as a UTC time, so stepping is not affected by DST
+func wall(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+// instants every instant in loc showing wall clock w, earliest first:
+// two inside an overlap, and the end of the gap inside a DST gap
+func instants(w time.Time, loc *time.Location) []time.Time {
+	y, m, d := w.Date()
+	// offsets in effect the day before and after, a transition on this day changes them
+	_, before := time.Date(y, m, d-1, w.Hour(), 0, 0, 0, loc).Zone()
+	_, after := time.Date(y, m, d+1, w.Hour(), 0, 0, 0, loc).Zone()
+
+	var result []time.Time
+	for _, offset := range []int{before, after} {
+		t := w.Add(-time.Duration(offset) * time.Second).In(loc)
+		if wall(t).Equal(w) && (len(result) == 0 || !result[0].Equal(t)) {
+			result = append(result, t)
+		}
+	}
+	if len(result) == 2 && result[1].Before(result[0]) {
+		result[0], result[1] = result[1], result[0]
+	}
+	if len(result) == 0 {
+		// w read with the offset before the gap lands after it, in the zone that ends the gap
+		start, _ := w.Add(-time.Duration(before) * time.Second).In(loc).ZoneBounds()
+		result = append(result, start)
+	}
+
+	return result
+}
+
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+func isWildcard(s string) bool {
+	return s == "*" || s == "?"
+}
+
+func parseField(s string, f field) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(s, ",") {
+		bits, e := parseRange(part, f)
+		if e != nil {
+			return 0, e
+		}
+		set |= bits
+	}
+
+	return set, nil
+}
+
+func parseRange(s string, f field) (uint64, error) {
+	expr, stepExpr, hasStep := strings.Cut(s, "/")
+	step := 1
+	if hasStep {
+		n, e := strconv.Atoi(stepExpr)
+		if e != nil || n < 1 {
+			return 0, fmt.Errorf("cron: invalid step %q in %s field", stepExpr, f.name)
+		}
+		step = n
+	}
+
+	var lo, hi int
+	switch {
+	case isWildcard(expr):
+		lo, hi = f.min, f.max
+		if f.name == dowField.name {
+			hi = 6
+		}
+	default:
+		loExpr, hiExpr, isRange := strings.Cut(expr, "-")
+		var e error
+		if lo, e = parseValue(loExpr, f); e != nil {
+			return 0, e
+		}
+		hi = lo
+		if isRange {
+			if hi, e = parseValue(hiExpr, f); e != nil {
+				return 0, e
+			}
+		} else if hasStep {
+			hi = f.max
+		}
+	}
+
+	if lo > hi {
+		return 0, fmt.Errorf("cron: invalid range %q in %s field", expr, f.name)
+	}
+
+	var set uint64
+	for v := lo; v <= hi; v += step {
+		set |= 1 << uint(v)
+	}
+
+	return set, nil
+}
+
+func parseValue(s string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, e := strconv.Atoi(s)
+	if e != nil {
+		return 0, fmt.Errorf("cron: invalid value %q in %s field", s, f.name)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("cron: value %d out of range [%d, %d] in %s field", v, f.min, f.max, f.name)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,154 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/enorith/supports/carbon"
+	"github.com/enorith/supports/carbon/cron"
+)
+
+const layout = "2006-01-02 15:04:05 MST"
+
+func TestNext(t *testing.T) {
+	// Tuesday
+	from := carbon.New(time.Date(2024, 3, 5, 10, 30, 15, 0, time.UTC), time.UTC)
+
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{"* * * * *", "2024-03-05 10:31:00 UTC"},
+		{"*/15 * * * *", "2024-03-05 10:45:00 UTC"},
+		{"*/20 * * * * *", "2024-03-05 10:30:20 UTC"},
+		{"0 9 * * mon-fri", "2024-03-06 09:00:00 UTC"},
+		{"0 0 1 * *", "2024-04-01 00:00:00 UTC"},
+		{"30 8 15 jan,jun *", "2024-06-15 08:30:00 UTC"},
+		{"0 12 13 * 5", "2024-03-08 12:00:00 UTC"},
+		{"0 0 29 2 *", "2028-02-29 00:00:00 UTC"},
+		{"0 0 * * 7", "2024-03-10 00:00:00 UTC"},
+		{"@hourly", "2024-03-05 11:00:00 UTC"},
+		{"@daily", "2024-03-06 00:00:00 UTC"},
+		{"@weekly", "2024-03-10 00:00:00 UTC"},
+		{"@yearly", "2025-01-01 00:00:00 UTC"},
+		{"10-40/10 10 5 3 ?", "2024-03-05 10:40:00 UTC"},
+	}
+
+	for _, cs := range cases {
+		s, e := cron.Parse(cs.expr)
+		if e != nil {
+			t.Errorf("%q: %v", cs.expr, e)
+			continue
+		}
+		if got := s.Next(from).GetTime().Format(layout); got != cs.want {
+			t.Errorf("%q: expected %s, got %s", cs.expr, cs.want, got)
+		}
+	}
+}
+
+func TestPrev(t *testing.T) {
+	from := carbon.New(time.Date(2024, 3, 5, 10, 30, 0, 0, time.UTC), time.UTC)
+
+	cases := []struct {
+		expr string
+		want string
+	}{
+		{"* * * * *", "2024-03-05 10:29:00 UTC"},
+		{"30 10 * * *", "2024-03-04 10:30:00 UTC"},
+		{"0 9 * * mon-fri", "2024-03-05 09:00:00 UTC"},
+		{"0 0 1 * *", "2024-03-01 00:00:00 UTC"},
+		{"0 0 29 2 *", "2024-02-29 00:00:00 UTC"},
+		{"@yearly", "2024-01-01 00:00:00 UTC"},
+	}
+
+	for _, cs := range cases {
+		if got := cron.MustParse(cs.expr).Prev(from).GetTime().Format(layout); got != cs.want {
+			t.Errorf("%q: expected %s, got %s", cs.expr, cs.want, got)
+		}
+	}
+}
+
+func TestDST(t *testing.T) {
+	berlin, e := time.LoadLocation("Europe/Berlin")
+	if e != nil {
+		t.Skip(e)
+	}
+
+	collect := func(expr string, from carbon.Carbon, n int) []string {
+		var got []string
+		cron.MustParse(expr).Iter(from)(func(c carbon.Carbon) bool {
+			got = append(got, c.GetTime().Format(layout))
+			return len(got) < n
+		})
+		return got
+	}
+
+	cases := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{
+			"gap fires at the end of the gap",
+			collect("30 2 * * *", carbon.New(time.Date(2024, 3, 30, 12, 0, 0, 0, berlin), berlin), 3),
+			[]string{"2024-03-31 03:00:00 CEST", "2024-04-01 02:30:00 CEST", "2024-04-02 02:30:00 CEST"},
+		},
+		{
+			"gap hourly",
+			collect("0 * * * *", carbon.New(time.Date(2024, 3, 31, 0, 30, 0, 0, berlin), berlin), 3),
+			[]string{"2024-03-31 01:00:00 CET", "2024-03-31 03:00:00 CEST", "2024-03-31 04:00:00 CEST"},
+		},
+		{
+			"gap fires once for several wall times",
+			collect("*/20 2-3 * * *", carbon.New(time.Date(2024, 3, 31, 1, 0, 0, 0, berlin), berlin), 3),
+			[]string{"2024-03-31 03:00:00 CEST", "2024-03-31 03:20:00 CEST", "2024-03-31 03:40:00 CEST"},
+		},
+		{
+			"overlap fires once at first occurrence",
+			collect("30 2 * * *", carbon.New(time.Date(2024, 10, 26, 12, 0, 0, 0, berlin), berlin), 2),
+			[]string{"2024-10-27 02:30:00 CEST", "2024-10-28 02:30:00 CET"},
+		},
+		{
+			"overlap hourly",
+			collect("0 * * * *", carbon.New(time.Date(2024, 10, 27, 1, 30, 0, 0, berlin), berlin), 3),
+			[]string{"2024-10-27 02:00:00 CEST", "2024-10-27 03:00:00 CET", "2024-10-27 04:00:00 CET"},
+		},
+	}
+
+	for _, cs := range cases {
+		if len(cs.got) != len(cs.want) {
+			t.Errorf("%s: expected %v, got %v", cs.name, cs.want, cs.got)
+			continue
+		}
+		for i := range cs.want {
+			if cs.got[i] != cs.want[i] {
+				t.Errorf("%s: expected %v, got %v", cs.name, cs.want, cs.got)
+				break
+			}
+		}
+	}
+
+	// reference inside the repeated hour
+	second := carbon.New(time.Date(2024, 10, 27, 1, 10, 0, 0, time.UTC), berlin)
+	if got := cron.MustParse("20 2 * * *").Next(second).GetTime().Format(layout); got != "2024-10-27 02:20:00 CET" {
+		t.Errorf("expected second occurrence, got %s", got)
+	}
+	if got := cron.MustParse("30 2 * * *").Prev(carbon.New(time.Date(2024, 3, 31, 12, 0, 0, 0, berlin), berlin)).GetTime().Format(layout); got != "2024-03-31 03:00:00 CEST" {
+		t.Errorf("expected end of gap, got %s", got)
+	}
+	if got := cron.MustParse("30 2 * * *").Prev(carbon.New(time.Date(2024, 3, 31, 3, 0, 0, 0, berlin), berlin)).GetTime().Format(layout); got != "2024-03-30 02:30:00 CET" {
+		t.Errorf("expected previous day, got %s", got)
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "@every"} {
+		if _, e := cron.Parse(expr); e == nil {
+			t.Errorf("%q: expected error", expr)
+		}
+	}
+
+	if c := cron.MustParse("0 0 30 2 *").Next(carbon.Now()); c.Valid {
+		t.Errorf("expected invalid carbon for a schedule that never fires, got %s", c)
+	}
+}
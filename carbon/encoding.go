@@ -0,0 +1,149 @@
+package carbon
+
+import (
+	"encoding/binary"
+	"errors"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// binaryVersion first byte of MarshalBinary output
+const binaryVersion byte = 1
+
+// MarshalText RFC 3339 with nanoseconds, invalid carbon is marshalled as empty text
+func (c Carbon) MarshalText() ([]byte, error) {
+	if !c.Valid {
+		return []byte{}, nil
+	}
+
+	return c.t.MarshalText()
+}
+
+// UnmarshalText accept RFC 3339 and the factory ParseLayouts, empty text is unmarshalled as invalid carbon
+func (c *Carbon) UnmarshalText(data []byte) error {
+	if len(data) == 0 {
+		*c = Carbon{}
+		return nil
+	}
+
+	f := c.getFactory()
+	var t time.Time
+	if e := t.UnmarshalText(data); e == nil {
+		*c = f.New(t, t.Location())
+		return nil
+	}
+
+	cb, e := f.Parse(string(data), nil)
+	if e != nil {
+		return e
+	}
+	*c = cb
+	return nil
+}
+
+// MarshalBinary encode the instant without monotonic reading, the location name and the locale
+func (c Carbon) MarshalBinary() ([]byte, error) {
+	if !c.Valid {
+		return []byte{binaryVersion, 0}, nil
+	}
+
+	t, e := c.t.MarshalBinary()
+	if e != nil {
+		return nil, e
+	}
+
+	data := []byte{binaryVersion, 1}
+	for _, s := range []string{c.t.Location().String(), c.locale} {
+		data = binary.AppendUvarint(data, uint64(len(s)))
+		data = append(data, s...)
+	}
+
+	return append(data, t...), nil
+}
+
+// UnmarshalBinary decode MarshalBinary output, locations missing from the
+// time zone database fall back to a fixed zone with the encoded offset
+func (c *Carbon) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != binaryVersion {
+		return errors.New("carbon: invalid binary data")
+	}
+	if data[1] == 0 {
+		*c = Carbon{}
+		return nil
+	}
+
+	data = data[2:]
+	var fields [2]string
+	for i := range fields {
+		n, size := binary.Uvarint(data)
+		if size <= 0 || uint64(len(data)-size) < n {
+			return errors.New("carbon: invalid binary data")
+		}
+		fields[i], data = string(data[size:size+int(n)]), data[size+int(n):]
+	}
+
+	var t time.Time
+	if e := t.UnmarshalBinary(data); e != nil {
+		return e
+	}
+
+	name, locale := fields[0], fields[1]
+	switch name {
+	case "UTC":
+		t = t.UTC()
+	case "Local":
+		t = t.Local()
+	default:
+		if loc, e := time.LoadLocation(name); e == nil {
+			t = t.In(loc)
+		} else {
+			_, offset := t.Zone()
+			t = t.In(time.FixedZone(name, offset))
+		}
+	}
+
+	*c = Carbon{t: t, locale: locale, factory: c.factory, Valid: true}
+	return nil
+}
+
+func (c Carbon) GobEncode() ([]byte, error) {
+	return c.MarshalBinary()
+}
+
+func (c *Carbon) GobDecode(data []byte) error {
+	return c.UnmarshalBinary(data)
+}
+
+// MarshalYAML RFC 3339 with nanoseconds, invalid carbon is marshalled as null
+func (c Carbon) MarshalYAML() (interface{}, error) {
+	if !c.Valid {
+		return nil, nil
+	}
+
+	return c.t.Format(time.RFC3339Nano), nil
+}
+
+// UnmarshalYAML accept what UnmarshalText does and integers as unix seconds or milliseconds,
+// null and empty values are unmarshalled as invalid carbon
+func (c *Carbon) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind != yaml.ScalarNode {
+		return errors.New("carbon: can not unmarshal yaml " + value.Tag)
+	}
+
+	switch value.ShortTag() {
+	case "!!null":
+		*c = Carbon{}
+		return nil
+	case "!!int":
+		n, e := strconv.ParseInt(value.Value, 0, 64)
+		if e != nil {
+			return e
+		}
+		*c = c.getFactory().fromEpoch(n)
+		return nil
+	}
+
+	return c.UnmarshalText([]byte(value.Value))
+}
@@ -0,0 +1,43 @@
+package carbon
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ParseError returned when a value can not be parsed to carbon
+type ParseError struct {
+	Value string
+	// Layouts attempted, in order
+	Layouts []string
+	// Errors underlying parse error of each failed layout
+	Errors []*time.ParseError
+	// Matched layouts that parsed to different instants, only set by ParseStrict
+	Matched []string
+}
+
+func (e *ParseError) Error() string {
+	if len(e.Matched) > 1 {
+		return fmt.Sprintf("carbon: ambiguous value %q, matches layouts: %s", e.Value, strings.Join(e.Matched, ", "))
+	}
+
+	return fmt.Sprintf("carbon: can not parse %q, expected one of layouts: %s", e.Value, strings.Join(e.Layouts, ", "))
+}
+
+func (e *ParseError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+func (e *ParseError) add(layout, value string, err error) {
+	pe, ok := err.(*time.ParseError)
+	if !ok {
+		pe = &time.ParseError{Layout: layout, Value: value, Message: ": " + err.Error()}
+	}
+	e.Errors = append(e.Errors, pe)
+}
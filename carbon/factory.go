@@ -0,0 +1,284 @@
+package carbon
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config settings used to create, parse and format carbon
+type Config struct {
+	DateTimeFormat       string
+	DateFormat           string
+	WeekStartDay         time.Weekday
+	Timezone             *time.Location
+	ParseLayouts         []string
+	FiscalYearStartMonth time.Month
+	JSONFormat           JSONFormat
+	Locale               string
+}
+
+// Factory creates carbon with its own config, carbon created by a factory keeps
+// using that config for default formats, week start, fiscal year, JSON and locale.
+// It is safe for concurrent use.
+type Factory struct {
+	config Config
+	// global factory reads and writes the package level variables
+	global bool
+	mu     sync.RWMutex
+}
+
+var defaultFactory = &Factory{global: true}
+
+// NewFactory create a factory from DefaultConfig updated by configure, settings left
+// untouched keep the package defaults. ParseLayouts defaults to the factory
+// DateTimeFormat, DateFormat and RFC 3339 when configure does not set it.
+//
+//	tenant := carbon.NewFactory(func(c *carbon.Config) {
+//		c.Timezone = shanghai
+//	})
+func NewFactory(configure ...func(c *Config)) *Factory {
+	config := DefaultConfig()
+	config.ParseLayouts = nil
+	for _, fn := range configure {
+		fn(&config)
+	}
+	if config.ParseLayouts == nil {
+		config.ParseLayouts = []string{config.DateTimeFormat, config.DateFormat, time.RFC3339}
+	}
+
+	config.ParseLayouts = append([]string(nil), config.ParseLayouts...)
+	return &Factory{config: config}
+}
+
+// DefaultFactory factory backed by the package level variables, used by package functions
+func DefaultFactory() *Factory {
+	return defaultFactory
+}
+
+// DefaultConfig snapshot of the package level variables
+func DefaultConfig() Config {
+	c := defaultFactory.snapshot()
+	c.ParseLayouts = append([]string(nil), c.ParseLayouts...)
+	return c
+}
+
+// Configure update the package level variables without racing readers,
+// prefer it over assigning the variables once goroutines are running
+func Configure(fn func(c *Config)) {
+	defaultFactory.Configure(fn)
+}
+
+// Config snapshot of the factory config
+func (f *Factory) Config() Config {
+	c := f.snapshot()
+	c.ParseLayouts = append([]string(nil), c.ParseLayouts...)
+	return c
+}
+
+// Configure update the factory config
+func (f *Factory) Configure(fn func(c *Config)) {
+	if f.global {
+		mu.Lock()
+		defer mu.Unlock()
+		c := globalConfig()
+		c.ParseLayouts = append([]string(nil), c.ParseLayouts...)
+		fn(&c)
+		DefaultDateTimeFormat, DefaultDateFormat = c.DateTimeFormat, c.DateFormat
+		WeekStartDay, Timezone, ParseLoyouts = c.WeekStartDay, c.Timezone, c.ParseLayouts
+		FiscalYearStartMonth, DefaultJSONFormat, DefaultLocale = c.FiscalYearStartMonth, c.JSONFormat, c.Locale
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := f.config
+	c.ParseLayouts = append([]string(nil), c.ParseLayouts...)
+	fn(&c)
+	f.config = c
+}
+
+// AddParseLayouts append layouts tried by Parse
+func (f *Factory) AddParseLayouts(layouts ...string) {
+	f.Configure(func(c *Config) {
+		c.ParseLayouts = append(c.ParseLayouts, layouts...)
+	})
+}
+
+// New create carbon in tz, or the factory timezone if tz is omitted
+func (f *Factory) New(t time.Time, tz ...*time.Location) Carbon {
+	if len(tz) > 0 && tz[0] != nil {
+		t = t.In(tz[0])
+	} else if loc := f.snapshot().Timezone; loc != nil {
+		t = t.In(loc)
+	}
+
+	c := Carbon{t: t, Valid: true}
+	if !f.global {
+		c.factory = f
+	}
+	return c
+}
+
+func (f *Factory) Now(tz ...*time.Location) Carbon {
+	return f.New(clockNow(), tz...)
+}
+
+func (f *Factory) Today() Carbon {
+	return f.Now().StartOfDay()
+}
+
+func (f *Factory) Tomorrow() Carbon {
+	return f.Now().AddDay().StartOfDay()
+}
+
+// FromTimestamp create carbon from unix seconds
+func (f *Factory) FromTimestamp(sec int64, tz ...*time.Location) Carbon {
+	return f.New(time.Unix(sec, 0), tz...)
+}
+
+func (f *Factory) FromTimestampMilli(msec int64, tz ...*time.Location) Carbon {
+	return f.New(time.UnixMilli(msec), tz...)
+}
+
+func (f *Factory) FromTimestampMicro(usec int64, tz ...*time.Location) Carbon {
+	return f.New(time.UnixMicro(usec), tz...)
+}
+
+func (f *Factory) FromTimestampNano(nsec int64, tz ...*time.Location) Carbon {
+	return f.New(time.Unix(0, nsec), tz...)
+}
+
+// FromDate create carbon at the start of the given day, out of range values are normalized like time.Date
+func (f *Factory) FromDate(year int, month time.Month, day int, tz ...*time.Location) Carbon {
+	return f.FromDateTime(year, month, day, 0, 0, 0, tz...)
+}
+
+func (f *Factory) FromDateTime(year int, month time.Month, day, hour, min, sec int, tz ...*time.Location) Carbon {
+	loc := f.location(tz)
+	return f.New(time.Date(year, month, day, hour, min, sec, 0, loc), loc)
+}
+
+// FromTime create carbon today at the given time of day
+func (f *Factory) FromTime(hour, min, sec int, tz ...*time.Location) Carbon {
+	loc := f.location(tz)
+	y, m, d := clockNow().In(loc).Date()
+	return f.New(time.Date(y, m, d, hour, min, sec, 0, loc), loc)
+}
+
+// Parse try each layout in order, defaults to the factory ParseLayouts and timezone
+func (f *Factory) Parse(value string, tz *time.Location, layout ...string) (Carbon, error) {
+	value = strings.TrimSpace(value)
+	layout, tz = f.parseArgs(tz, layout)
+
+	pe := &ParseError{Value: value, Layouts: append([]string(nil), layout...)}
+	for _, v := range layout {
+		ti, err := time.ParseInLocation(v, value, tz)
+		if err == nil {
+			return f.New(ti, tz), nil
+		}
+		pe.add(v, value, err)
+	}
+
+	return Carbon{}, pe
+}
+
+// ParseStrict parse value without trimming, every layout is attempted
+// and an error is returned if layouts match with different instants
+func (f *Factory) ParseStrict(value string, tz *time.Location, layout ...string) (Carbon, error) {
+	layout, tz = f.parseArgs(tz, layout)
+
+	var (
+		result             time.Time
+		matched, ambiguous bool
+	)
+	pe := &ParseError{Value: value, Layouts: append([]string(nil), layout...)}
+	for _, v := range layout {
+		ti, err := time.ParseInLocation(v, value, tz)
+		if err != nil {
+			pe.add(v, value, err)
+			continue
+		}
+		if matched && !ti.Equal(result) {
+			ambiguous = true
+		}
+		if !matched {
+			result, matched = ti, true
+		}
+		pe.Matched = append(pe.Matched, v)
+	}
+
+	if !matched || ambiguous {
+		return Carbon{}, pe
+	}
+
+	return f.New(result, tz), nil
+}
+
+func (f *Factory) parseArgs(tz *time.Location, layout []string) ([]string, *time.Location) {
+	if len(layout) < 1 {
+		layout = f.snapshot().ParseLayouts
+	}
+
+	return layout, f.location([]*time.Location{tz})
+}
+
+// location tz if given, otherwise the factory timezone
+func (f *Factory) location(tz []*time.Location) *time.Location {
+	if len(tz) > 0 && tz[0] != nil {
+		return tz[0]
+	}
+	if loc := f.snapshot().Timezone; loc != nil {
+		return loc
+	}
+
+	return time.Local
+}
+
+// fromEpoch numbers with magnitude of unixMilliThreshold or greater are milliseconds, otherwise seconds
+func (f *Factory) fromEpoch(n int64, tz ...*time.Location) Carbon {
+	if n >= unixMilliThreshold || n <= -unixMilliThreshold {
+		return f.FromTimestampMilli(n, tz...)
+	}
+
+	return f.FromTimestamp(n, tz...)
+}
+
+// snapshot config sharing ParseLayouts, callers must not modify it
+func (f *Factory) snapshot() Config {
+	if f.global {
+		mu.RLock()
+		defer mu.RUnlock()
+		return globalConfig()
+	}
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.config
+}
+
+// globalConfig read the package level variables, mu must be held
+func globalConfig() Config {
+	return Config{
+		DateTimeFormat:       DefaultDateTimeFormat,
+		DateFormat:           DefaultDateFormat,
+		WeekStartDay:         WeekStartDay,
+		Timezone:             Timezone,
+		ParseLayouts:         ParseLoyouts,
+		FiscalYearStartMonth: FiscalYearStartMonth,
+		JSONFormat:           DefaultJSONFormat,
+		Locale:               DefaultLocale,
+	}
+}
+
+func (c Carbon) getFactory() *Factory {
+	if c.factory == nil {
+		return defaultFactory
+	}
+
+	return c.factory
+}
+
+func (c Carbon) config() Config {
+	return c.getFactory().snapshot()
+}
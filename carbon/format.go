@@ -0,0 +1,398 @@
+package carbon
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// formatSegment piece of a translated format, fn overrides layout when formatting,
+// a segment with fn and without layout can not be parsed
+type formatSegment struct {
+	layout  string
+	literal bool
+	fn      func(t time.Time) string
+	// translate used instead of layout and fn when formatting with a locale
+	translate func(l Locale, t time.Time) string
+}
+
+type compiledFormat []formatSegment
+
+var (
+	phpTokens = map[byte]formatSegment{
+		'd': {layout: "02"},
+		'D': {layout: "Mon", translate: translateShortWeekday},
+		'j': {layout: "2"},
+		'l': {layout: "Monday", translate: translateWeekday},
+		'N': {fn: isoWeekdayString},
+		'S': {
+			fn: func(t time.Time) string { return ordinalSuffix(t.Day()) },
+			translate: func(l Locale, t time.Time) string {
+				return strings.TrimPrefix(l.Ordinal(t.Day(), Day), strconv.Itoa(t.Day()))
+			},
+		},
+		'w': {fn: func(t time.Time) string { return strconv.Itoa(int(t.Weekday())) }},
+		'z': {fn: func(t time.Time) string { return strconv.Itoa(t.YearDay() - 1) }},
+		'W': {fn: func(t time.Time) string { _, w := t.ISOWeek(); return pad(w, 2) }},
+		'F': {layout: "January", translate: translateMonth},
+		'm': {layout: "01"},
+		'M': {layout: "Jan", translate: translateShortMonth},
+		'n': {layout: "1"},
+		't': {fn: func(t time.Time) string { return strconv.Itoa(daysInMonth(t.Year(), t.Month())) }},
+		'L': {fn: func(t time.Time) string {
+			if daysInMonth(t.Year(), time.February) == 29 {
+				return "1"
+			}
+			return "0"
+		}},
+		'o': {fn: func(t time.Time) string { y, _ := t.ISOWeek(); return strconv.Itoa(y) }},
+		'Y': {layout: "2006"},
+		'y': {layout: "06"},
+		'a': {layout: "pm", translate: translateMeridiemLower},
+		'A': {layout: "PM", translate: translateMeridiem},
+		'g': {layout: "3"},
+		'G': {layout: "15", fn: func(t time.Time) string { return strconv.Itoa(t.Hour()) }},
+		'h': {layout: "03"},
+		'H': {layout: "15"},
+		'i': {layout: "04"},
+		's': {layout: "05"},
+		'u': {layout: "000000", fn: func(t time.Time) string { return pad(t.Nanosecond()/1e3, 6) }},
+		'v': {layout: "000", fn: func(t time.Time) string { return pad(t.Nanosecond()/1e6, 3) }},
+		'e': {fn: func(t time.Time) string { return t.Location().String() }},
+		'T': {layout: "MST"},
+		'P': {layout: "-07:00"},
+		'O': {layout: "-0700"},
+		'p': {layout: "Z07:00"},
+		'Z': {fn: func(t time.Time) string { _, offset := t.Zone(); return strconv.Itoa(offset) }},
+		'c': {layout: "2006-01-02T15:04:05-07:00"},
+		'r': {layout: "Mon, 02 Jan 2006 15:04:05 -0700"},
+		'U': {fn: func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }},
+	}
+
+	momentTokens = map[string]formatSegment{
+		"YYYY": {layout: "2006"},
+		"YY":   {layout: "06"},
+		"Q":    {fn: func(t time.Time) string { return strconv.Itoa((int(t.Month())-1)/3 + 1) }},
+		"MMMM": {layout: "January", translate: translateMonth},
+		"MMM":  {layout: "Jan", translate: translateShortMonth},
+		"MM":   {layout: "01"},
+		"Mo": {
+			fn:        func(t time.Time) string { return ordinal(int(t.Month())) },
+			translate: func(l Locale, t time.Time) string { return l.Ordinal(int(t.Month()), Month) },
+		},
+		"M":    {layout: "1"},
+		"DDDD": {layout: "002"},
+		"DDDo": {
+			fn:        func(t time.Time) string { return ordinal(t.YearDay()) },
+			translate: func(l Locale, t time.Time) string { return l.Ordinal(t.YearDay(), Day) },
+		},
+		"DDD": {fn: func(t time.Time) string { return strconv.Itoa(t.YearDay()) }},
+		"Do": {
+			fn:        func(t time.Time) string { return ordinal(t.Day()) },
+			translate: func(l Locale, t time.Time) string { return l.Ordinal(t.Day(), Day) },
+		},
+		"DD":   {layout: "02"},
+		"D":    {layout: "2"},
+		"dddd": {layout: "Monday", translate: translateWeekday},
+		"ddd":  {layout: "Mon", translate: translateShortWeekday},
+		"dd": {
+			fn:        func(t time.Time) string { return t.Weekday().String()[:2] },
+			translate: func(l Locale, t time.Time) string { return l.MinWeekdays[t.Weekday()] },
+		},
+		"do": {fn: func(t time.Time) string { return ordinal(int(t.Weekday())) }},
+		"d":  {fn: func(t time.Time) string { return strconv.Itoa(int(t.Weekday())) }},
+		"E":  {fn: isoWeekdayString},
+		"WW": {fn: func(t time.Time) string { _, w := t.ISOWeek(); return pad(w, 2) }},
+		"Wo": {
+			fn:        func(t time.Time) string { _, w := t.ISOWeek(); return ordinal(w) },
+			translate: func(l Locale, t time.Time) string { _, w := t.ISOWeek(); return l.Ordinal(w, Week) },
+		},
+		"W":    {fn: func(t time.Time) string { _, w := t.ISOWeek(); return strconv.Itoa(w) }},
+		"GGGG": {fn: func(t time.Time) string { y, _ := t.ISOWeek(); return strconv.Itoa(y) }},
+		"HH":   {layout: "15"},
+		"H":    {layout: "15", fn: func(t time.Time) string { return strconv.Itoa(t.Hour()) }},
+		"hh":   {layout: "03"},
+		"h":    {layout: "3"},
+		"mm":   {layout: "04"},
+		"m":    {layout: "4"},
+		"ss":   {layout: "05"},
+		"s":    {layout: "5"},
+		"SSS":  {layout: "000", fn: func(t time.Time) string { return pad(t.Nanosecond()/1e6, 3) }},
+		"SS":   {layout: "00", fn: func(t time.Time) string { return pad(t.Nanosecond()/1e7, 2) }},
+		"S":    {layout: "0", fn: func(t time.Time) string { return strconv.Itoa(t.Nanosecond() / 1e8) }},
+		"A":    {layout: "PM", translate: translateMeridiem},
+		"a":    {layout: "pm", translate: translateMeridiemLower},
+		"ZZ":   {layout: "-0700"},
+		"Z":    {layout: "-07:00"},
+		"X":    {fn: func(t time.Time) string { return strconv.FormatInt(t.Unix(), 10) }},
+		"x":    {fn: func(t time.Time) string { return strconv.FormatInt(t.UnixMilli(), 10) }},
+	}
+	// momentTokenKeys longest first, so "MMMM" wins over "MM"
+	momentTokenKeys []string
+
+	// formatCache compiled formats, at most maxCachedFormats so caller supplied formats can not grow it forever
+	formatCache     sync.Map
+	formatCacheSize atomic.Int64
+)
+
+const maxCachedFormats = 256
+
+func init() {
+	for k := range momentTokens {
+		momentTokenKeys = append(momentTokenKeys, k)
+	}
+	sort.Slice(momentTokenKeys, func(i, j int) bool {
+		if len(momentTokenKeys[i]) != len(momentTokenKeys[j]) {
+			return len(momentTokenKeys[i]) > len(momentTokenKeys[j])
+		}
+		return momentTokenKeys[i] < momentTokenKeys[j]
+	})
+}
+
+// FormatPHP format with PHP date() tokens, e.g. "Y-m-d H:i:s" or "l, F jS",
+// a backslash escapes the next character
+func (c Carbon) FormatPHP(format string) string {
+	return compilePHP(format).format(c.t, nil)
+}
+
+// FormatMoment format with moment.js tokens, e.g. "YYYY-MM-DD HH:mm:ss" or "dddd, MMMM Do",
+// text in square brackets is escaped
+func (c Carbon) FormatMoment(format string) string {
+	return compileMoment(format).format(c.t, nil)
+}
+
+// ParsePHP parse value with PHP date() tokens, tokens without a Go layout equivalent
+// (N, S, w, z, W, t, L, o, e, Z, U) are not supported
+func ParsePHP(value, format string, tz *time.Location) (Carbon, error) {
+	return compilePHP(format).parse(value, tz)
+}
+
+// ParseMoment parse value with moment.js tokens, ordinal, week, weekday number
+// and unix tokens are not supported
+func ParseMoment(value, format string, tz *time.Location) (Carbon, error) {
+	return compileMoment(format).parse(value, tz)
+}
+
+func compilePHP(format string) compiledFormat {
+	key := "php:" + format
+	if cf, ok := formatCache.Load(key); ok {
+		return cf.(compiledFormat)
+	}
+
+	var cf compiledFormat
+	for i := 0; i < len(format); i++ {
+		ch := format[i]
+		if ch == '\\' && i+1 < len(format) {
+			i++
+			cf = cf.appendLiteral(format[i : i+1])
+			continue
+		}
+		if seg, ok := phpTokens[ch]; ok {
+			cf = append(cf, seg)
+			continue
+		}
+		cf = cf.appendLiteral(format[i : i+1])
+	}
+
+	cacheFormat(key, cf)
+	return cf
+}
+
+func compileMoment(format string) compiledFormat {
+	key := "moment:" + format
+	if cf, ok := formatCache.Load(key); ok {
+		return cf.(compiledFormat)
+	}
+
+	var cf compiledFormat
+	for i := 0; i < len(format); {
+		if format[i] == '[' {
+			if end := strings.IndexByte(format[i:], ']'); end > 0 {
+				cf = cf.appendLiteral(format[i+1 : i+end])
+				i += end + 1
+				continue
+			}
+		}
+
+		matched := false
+		for _, k := range momentTokenKeys {
+			if strings.HasPrefix(format[i:], k) {
+				cf = append(cf, momentTokens[k])
+				i += len(k)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			cf = cf.appendLiteral(format[i : i+1])
+			i++
+		}
+	}
+
+	cacheFormat(key, cf)
+	return cf
+}
+
+// cacheFormat store cf unless the cache is full, formats beyond the limit are compiled on every use
+func cacheFormat(key string, cf compiledFormat) {
+	if formatCacheSize.Load() >= maxCachedFormats {
+		return
+	}
+	if _, loaded := formatCache.LoadOrStore(key, cf); !loaded {
+		formatCacheSize.Add(1)
+	}
+}
+
+func (cf compiledFormat) appendLiteral(s string) compiledFormat {
+	if s == "" {
+		return cf
+	}
+	if n := len(cf); n > 0 && cf[n-1].literal {
+		cf[n-1].layout += s
+		return cf
+	}
+
+	return append(cf, formatSegment{layout: s, literal: true})
+}
+
+// format t, names and ordinals are translated when locale is not nil
+func (cf compiledFormat) format(t time.Time, locale *Locale) string {
+	var b strings.Builder
+	for _, seg := range cf {
+		switch {
+		case seg.literal:
+			b.WriteString(seg.layout)
+		case locale != nil && seg.translate != nil:
+			b.WriteString(seg.translate(*locale, t))
+		case seg.fn != nil:
+			b.WriteString(seg.fn(t))
+		default:
+			b.WriteString(t.Format(seg.layout))
+		}
+	}
+
+	return b.String()
+}
+
+// layout translate to a Go reference layout, literals are copied as is,
+// parse handles literals that Go would read as tokens
+func (cf compiledFormat) layout() (string, error) {
+	var b strings.Builder
+	for _, seg := range cf {
+		if seg.layout == "" {
+			return "", errors.New("carbon: format contains tokens that can not be parsed")
+		}
+		b.WriteString(seg.layout)
+	}
+
+	return b.String(), nil
+}
+
+// parse value, literals containing Go layout tokens are matched as fixed text
+// and only the token runs between them are handed to Parse
+func (cf compiledFormat) parse(value string, tz *time.Location) (Carbon, error) {
+	layout, e := cf.layout()
+	if e != nil {
+		return Carbon{}, e
+	}
+
+	var runs, literals []string
+	run := ""
+	hasToken := false
+	for _, seg := range cf {
+		if !seg.literal {
+			run += seg.layout
+			continue
+		}
+		runs, literals, run = append(runs, run), append(literals, seg.layout), ""
+		hasToken = hasToken || containsLayoutToken(seg.layout)
+	}
+	runs = append(runs, run)
+	if !hasToken {
+		return Parse(value, tz, layout)
+	}
+
+	value = strings.TrimSpace(value)
+	var err error
+	var try func(rest string, i int, pieces []string) (Carbon, bool)
+	try = func(rest string, i int, pieces []string) (Carbon, bool) {
+		if i == len(literals) {
+			// NUL is not a layout token, it separates the runs in layout and value alike
+			c, e := Parse(strings.Join(append(pieces, rest), "\x00"), tz, strings.Join(runs, "\x00"))
+			if err == nil {
+				err = e
+			}
+			return c, e == nil
+		}
+		for from := 0; from <= len(rest); {
+			at := strings.Index(rest[from:], literals[i])
+			if at < 0 {
+				break
+			}
+			at += from
+			if c, ok := try(rest[at+len(literals[i]):], i+1, append(pieces[:len(pieces):len(pieces)], rest[:at])); ok {
+				return c, true
+			}
+			from = at + 1
+		}
+		return Carbon{}, false
+	}
+
+	if c, ok := try(value, 0, nil); ok {
+		return c, nil
+	}
+	if err == nil {
+		err = fmt.Errorf("carbon: can not parse %q, literals of the format not found", value)
+	}
+	return Carbon{}, err
+}
+
+// containsLayoutToken check if Go would read part of s as a layout token
+func containsLayoutToken(s string) bool {
+	t := time.Date(1999, 12, 31, 23, 59, 58, 987654321, time.FixedZone("XYZ", -9*3600-1800))
+	return t.Format(s) != s
+}
+
+func isoWeekdayString(t time.Time) string {
+	wd := int(t.Weekday())
+	if wd == 0 {
+		wd = 7
+	}
+
+	return strconv.Itoa(wd)
+}
+
+func ordinal(n int) string {
+	return strconv.Itoa(n) + ordinalSuffix(n)
+}
+
+// ordinalSuffix english ordinal suffix: st, nd, rd, th
+func ordinalSuffix(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return "th"
+	}
+	switch n % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+
+	return "th"
+}
+
+func pad(n, width int) string {
+	s := strconv.Itoa(n)
+	if len(s) < width {
+		s = strings.Repeat("0", width-len(s)) + s
+	}
+
+	return s
+}
@@ -0,0 +1,269 @@
+package carbon
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HumanLocale translations used by DiffForHumans
+type HumanLocale struct {
+	// Units singular and plural names of each unit
+	Units map[Unit][2]string
+	// ShortUnits abbreviated unit names, used when DiffOptions.Short is set
+	ShortUnits map[Unit]string
+	// Space between number and unit name in long form
+	Space string
+	// Delimiter between multiple parts, e.g. "1 day 3 hours"
+	Delimiter string
+
+	JustNow string
+	// Ago, FromNow, Before and After are patterns, %s is replaced with the diff
+	Ago     string
+	FromNow string
+	Before  string
+	After   string
+}
+
+// DiffOptions controls the output of DiffForHumansWith
+type DiffOptions struct {
+	// Short use abbreviated units, "3h" instead of "3 hours"
+	Short bool
+	// Parts maximum number of units to output, defaults to 1
+	Parts int
+	// Absolute omit the "ago"/"from now" modifiers
+	Absolute bool
+	// Locale registered locale name, defaults to the carbon locale
+	Locale string
+}
+
+var (
+	humanLocales = map[string]HumanLocale{
+		"en": {
+			Units: map[Unit][2]string{
+				Second: {"second", "seconds"},
+				Minute: {"minute", "minutes"},
+				Hour:   {"hour", "hours"},
+				Day:    {"day", "days"},
+				Week:   {"week", "weeks"},
+				Month:  {"month", "months"},
+				Year:   {"year", "years"},
+			},
+			ShortUnits: map[Unit]string{
+				Second: "s",
+				Minute: "m",
+				Hour:   "h",
+				Day:    "d",
+				Week:   "w",
+				Month:  "mo",
+				Year:   "y",
+			},
+			Space:     " ",
+			Delimiter: " ",
+			JustNow:   "just now",
+			Ago:       "%s ago",
+			FromNow:   "in %s",
+			Before:    "%s before",
+			After:     "%s after",
+		},
+		"zh-CN": {
+			Units: map[Unit][2]string{
+				Second: {"秒", "秒"},
+				Minute: {"分钟", "分钟"},
+				Hour:   {"小时", "小时"},
+				Day:    {"天", "天"},
+				Week:   {"周", "周"},
+				Month:  {"个月", "个月"},
+				Year:   {"年", "年"},
+			},
+			ShortUnits: map[Unit]string{
+				Second: "秒",
+				Minute: "分",
+				Hour:   "时",
+				Day:    "天",
+				Week:   "周",
+				Month:  "月",
+				Year:   "年",
+			},
+			JustNow: "刚刚",
+			Ago:     "%s前",
+			FromNow: "%s后",
+			Before:  "%s前",
+			After:   "%s后",
+		},
+	}
+	humanMu sync.RWMutex
+)
+
+func RegisterHumanLocale(name string, locale HumanLocale) {
+	humanMu.Lock()
+	defer humanMu.Unlock()
+	humanLocales[name] = locale
+}
+
+func GetHumanLocale(name string) (HumanLocale, bool) {
+	humanMu.RLock()
+	defer humanMu.RUnlock()
+	l, ok := humanLocales[name]
+	return l, ok
+}
+
+// DiffForHumans get the difference in a human readable format,
+// compared with now when other is omitted
+func (c Carbon) DiffForHumans(other ...Carbon) string {
+	return c.DiffForHumansWith(DiffOptions{}, other...)
+}
+
+func (c Carbon) DiffForHumansWith(opts DiffOptions, other ...Carbon) string {
+	name := opts.Locale
+	if name == "" {
+		name = c.LocaleName()
+	}
+	locale, ok := GetHumanLocale(name)
+	if !ok {
+		locale, _ = GetHumanLocale("en")
+	}
+
+	var ref Carbon
+	isNow := len(other) < 1
+	if isNow {
+		ref = c.getFactory().Now()
+	} else {
+		ref = other[0]
+	}
+
+	start, end := ref.t, c.t
+	future := end.After(start)
+	if !future {
+		start, end = end, start
+	}
+
+	parts := humanParts(start, end)
+	limit := opts.Parts
+	if limit < 1 {
+		limit = 1
+	}
+
+	var segments []string
+	for u := Year; u >= Second && len(segments) < limit; u-- {
+		if parts[u] == 0 {
+			continue
+		}
+		segments = append(segments, locale.unit(u, parts[u], opts.Short))
+	}
+
+	if len(segments) == 0 {
+		if isNow && !opts.Absolute {
+			return locale.JustNow
+		}
+		segments = append(segments, locale.unit(Second, 0, opts.Short))
+	}
+
+	diff := strings.Join(segments, locale.Delimiter)
+	if opts.Absolute {
+		return diff
+	}
+
+	var pattern string
+	switch {
+	case isNow && future:
+		pattern = locale.FromNow
+	case isNow:
+		pattern = locale.Ago
+	case future:
+		pattern = locale.After
+	default:
+		pattern = locale.Before
+	}
+
+	return strings.Replace(pattern, "%s", diff, 1)
+}
+
+func (l HumanLocale) unit(u Unit, n int, short bool) string {
+	num := strconv.Itoa(n)
+	if short {
+		return num + l.ShortUnits[u]
+	}
+	names := l.Units[u]
+	if n == 1 {
+		return num + l.Space + names[0]
+	}
+
+	return num + l.Space + names[1]
+}
+
+// humanParts split the period between start and end into calendar units, start must not be after end
+func humanParts(start, end time.Time) map[Unit]int {
+	years, months, days, rest := calendarDiff(start, end)
+
+	return map[Unit]int{
+		Year:   years,
+		Month:  months,
+		Week:   days / 7,
+		Day:    days % 7,
+		Hour:   int(rest / time.Hour),
+		Minute: int(rest % time.Hour / time.Minute),
+		Second: int(rest % time.Minute / time.Second),
+	}
+}
+
+// calendarDiff split the period between start and end into whole years, months and days,
+// rest is the remaining duration, start must not be after end
+func calendarDiff(start, end time.Time) (years, months, days int, rest time.Duration) {
+	total := monthsBetween(start, end)
+	anchor := addMonthsNoOverflow(start, total)
+	days = daysBetween(anchor, end)
+
+	return total / 12, total % 12, days, end.Sub(anchor.AddDate(0, 0, days))
+}
+
+// calendarDiffBefore like calendarDiff but counting back from start to end, end must not be after start
+func calendarDiffBefore(start, end time.Time) (years, months, days int, rest time.Duration) {
+	sy, sm, _ := start.Date()
+	ey, em, _ := end.Date()
+
+	total := (sy-ey)*12 + int(sm-em)
+	for total > 0 && addMonthsNoOverflow(start, -total).Before(end) {
+		total--
+	}
+	anchor := addMonthsNoOverflow(start, -total)
+
+	days = int(anchor.Sub(end) / (24 * time.Hour))
+	for anchor.AddDate(0, 0, -days-1).Compare(end) >= 0 {
+		days++
+	}
+	for days > 0 && anchor.AddDate(0, 0, -days).Before(end) {
+		days--
+	}
+
+	return total / 12, total % 12, days, anchor.AddDate(0, 0, -days).Sub(end)
+}
+
+// monthsBetween whole calendar months from start to end, start must not be after end,
+// a month from the 31st ends on the last day of a shorter month, so Jan 31 to Feb 28 is one month
+func monthsBetween(start, end time.Time) int {
+	end = end.In(start.Location())
+	sy, sm, _ := start.Date()
+	ey, em, _ := end.Date()
+
+	total := (ey-sy)*12 + int(em-sm)
+	for total > 0 && addMonthsNoOverflow(start, total).After(end) {
+		total--
+	}
+
+	return total
+}
+
+// daysBetween whole calendar days from start to end, start must not be after end
+func daysBetween(start, end time.Time) int {
+	days := int(end.Sub(start) / (24 * time.Hour))
+	for start.AddDate(0, 0, days+1).Compare(end) <= 0 {
+		days++
+	}
+	for days > 0 && start.AddDate(0, 0, days).After(end) {
+		days--
+	}
+
+	return days
+}
@@ -0,0 +1,259 @@
+package carbon
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interval calendar aware amount of time, months and years have no fixed duration
+// unlike time.Duration. Components share the same sign.
+type Interval struct {
+	Years   int
+	Months  int
+	Days    int
+	Hours   int
+	Minutes int
+	Seconds int
+	Nanos   int
+}
+
+var intervalRe = regexp.MustCompile(`^([+-])?P(?:(\d+)Y)?(?:(\d+)M)?(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)(?:[.,](\d{1,9}))?S)?)?$`)
+
+// ParseInterval parse an ISO 8601 duration like "P1Y2M10DT2H30M", "P2W" or "-PT1.5S",
+// weeks are converted to days
+func ParseInterval(s string) (Interval, error) {
+	m := intervalRe.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil || strings.HasSuffix(m[0], "P") || strings.HasSuffix(m[0], "T") {
+		return Interval{}, fmt.Errorf("carbon: invalid ISO 8601 duration %q", s)
+	}
+
+	// optional groups are empty and parse to 0, digits that overflow int are an error
+	var e error
+	num := func(i int) int {
+		if m[i] == "" || e != nil {
+			return 0
+		}
+		n, err := strconv.Atoi(m[i])
+		if err != nil {
+			e = fmt.Errorf("carbon: invalid ISO 8601 duration %q: %w", s, err)
+		}
+		return n
+	}
+
+	weeks, days := num(4), num(5)
+	if e == nil && weeks > (math.MaxInt-days)/7 {
+		e = fmt.Errorf("carbon: invalid ISO 8601 duration %q: days out of range", s)
+	}
+
+	i := Interval{
+		Years:   num(2),
+		Months:  num(3),
+		Days:    weeks*7 + days,
+		Hours:   num(6),
+		Minutes: num(7),
+		Seconds: num(8),
+	}
+	if e != nil {
+		return Interval{}, e
+	}
+	if frac := m[9]; frac != "" {
+		i.Nanos, _ = strconv.Atoi(frac + strings.Repeat("0", 9-len(frac)))
+	}
+	if m[1] == "-" {
+		i = i.Invert()
+	}
+
+	return i, nil
+}
+
+// MustParseInterval like ParseInterval but panics on error
+func MustParseInterval(s string) Interval {
+	i, e := ParseInterval(s)
+	if e != nil {
+		panic(e)
+	}
+
+	return i
+}
+
+// String ISO 8601 representation, "PT0S" for an empty interval
+func (i Interval) String() string {
+	if i.IsZero() {
+		return "PT0S"
+	}
+
+	var b strings.Builder
+	if i.isNegative() {
+		b.WriteByte('-')
+		i = i.Invert()
+	}
+	b.WriteByte('P')
+
+	writeUnit := func(n int, designator byte) {
+		if n != 0 {
+			b.WriteString(strconv.Itoa(n))
+			b.WriteByte(designator)
+		}
+	}
+	writeUnit(i.Years, 'Y')
+	writeUnit(i.Months, 'M')
+	writeUnit(i.Days, 'D')
+
+	if i.Hours != 0 || i.Minutes != 0 || i.Seconds != 0 || i.Nanos != 0 {
+		b.WriteByte('T')
+		writeUnit(i.Hours, 'H')
+		writeUnit(i.Minutes, 'M')
+		if i.Nanos != 0 {
+			frac := strings.TrimRight(fmt.Sprintf("%09d", i.Nanos), "0")
+			b.WriteString(strconv.Itoa(i.Seconds) + "." + frac + "S")
+		} else {
+			writeUnit(i.Seconds, 'S')
+		}
+	}
+
+	return b.String()
+}
+
+// ForHumans human readable interval like "1 year 2 months 10 days", weeks are not split from days
+func (i Interval) ForHumans(locale ...string) string {
+	name := defaultFactory.snapshot().Locale
+	if len(locale) > 0 {
+		name = locale[0]
+	}
+	l, ok := GetHumanLocale(name)
+	if !ok {
+		l, _ = GetHumanLocale("en")
+	}
+
+	var prefix string
+	if i.isNegative() {
+		prefix = "-"
+		i = i.Invert()
+	}
+
+	var parts []string
+	for _, p := range []struct {
+		unit Unit
+		n    int
+	}{{Year, i.Years}, {Month, i.Months}, {Day, i.Days}, {Hour, i.Hours}, {Minute, i.Minutes}, {Second, i.Seconds}} {
+		if p.n != 0 {
+			parts = append(parts, l.unit(p.unit, p.n, false))
+		}
+	}
+	if len(parts) == 0 {
+		parts = append(parts, l.unit(Second, 0, false))
+	}
+
+	return prefix + strings.Join(parts, l.Delimiter)
+}
+
+func (i Interval) Invert() Interval {
+	return Interval{-i.Years, -i.Months, -i.Days, -i.Hours, -i.Minutes, -i.Seconds, -i.Nanos}
+}
+
+func (i Interval) IsZero() bool {
+	return i == Interval{}
+}
+
+func (i Interval) isNegative() bool {
+	return i.Years < 0 || i.Months < 0 || i.Days < 0 || i.Hours < 0 || i.Minutes < 0 || i.Seconds < 0 || i.Nanos < 0
+}
+
+// clock the time part of the interval as duration
+func (i Interval) clock() time.Duration {
+	return time.Duration(i.Hours)*time.Hour + time.Duration(i.Minutes)*time.Minute +
+		time.Duration(i.Seconds)*time.Second + time.Duration(i.Nanos)
+}
+
+// AddInterval add years and months like AddMonthsNoOverflow, days like AddDate
+// and the time part as elapsed duration
+func (c Carbon) AddInterval(i Interval) Carbon {
+	c.t = addMonthsNoOverflow(c.t, i.Years*12+i.Months).AddDate(0, 0, i.Days).Add(i.clock())
+	return c
+}
+
+func (c Carbon) SubInterval(i Interval) Carbon {
+	return c.AddInterval(i.Invert())
+}
+
+// Diff calendar interval from carbon to other, negative if other is before carbon,
+// counted from carbon so c.AddInterval(c.Diff(other)) equals other.
+// Months from a day missing in a shorter month end on its last day, so Jan 31 to Mar 1 is P1M1D
+func (c Carbon) Diff(other Carbon) Interval {
+	start, end := c.t, other.t.In(c.t.Location())
+	negative := end.Before(start)
+
+	var years, months, days int
+	var rest time.Duration
+	if negative {
+		years, months, days, rest = calendarDiffBefore(start, end)
+	} else {
+		years, months, days, rest = calendarDiff(start, end)
+	}
+	i := Interval{
+		Years:   years,
+		Months:  months,
+		Days:    days,
+		Hours:   int(rest / time.Hour),
+		Minutes: int(rest % time.Hour / time.Minute),
+		Seconds: int(rest % time.Minute / time.Second),
+		Nanos:   int(rest % time.Second),
+	}
+	if negative {
+		return i.Invert()
+	}
+
+	return i
+}
+
+// ParseISOPeriod parse an ISO 8601 interval "start/end", "start/duration" or "duration/end"
+// into a daily Period, dates are parsed with Parse in tz
+func ParseISOPeriod(s string, tz *time.Location) (Period, error) {
+	left, right, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Period{}, fmt.Errorf("carbon: invalid ISO 8601 interval %q", s)
+	}
+
+	var (
+		start, end Carbon
+		e          error
+	)
+	switch {
+	case isISODuration(left):
+		if end, e = Parse(right, tz); e != nil {
+			return Period{}, e
+		}
+		i, e := ParseInterval(left)
+		if e != nil {
+			return Period{}, e
+		}
+		start = end.SubInterval(i)
+	case isISODuration(right):
+		if start, e = Parse(left, tz); e != nil {
+			return Period{}, e
+		}
+		i, e := ParseInterval(right)
+		if e != nil {
+			return Period{}, e
+		}
+		end = start.AddInterval(i)
+	default:
+		if start, e = Parse(left, tz); e != nil {
+			return Period{}, e
+		}
+		if end, e = Parse(right, tz); e != nil {
+			return Period{}, e
+		}
+	}
+
+	return NewPeriod(start, end), nil
+}
+
+func isISODuration(s string) bool {
+	s = strings.TrimLeft(strings.ToUpper(s), "+-")
+	return strings.HasPrefix(s, "P")
+}
@@ -0,0 +1,205 @@
+package carbon
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// JSONFormat output shape of Carbon.MarshalJSON
+type JSONFormat int
+
+const (
+	// FormatObject object with year, month, day, hour, minute, second, time, timezone, datetime and timestamp
+	FormatObject JSONFormat = iota
+	// FormatRFC3339 string like "2006-01-02T15:04:05+07:00"
+	FormatRFC3339
+	// FormatDateTime string in DefaultDateTimeFormat
+	FormatDateTime
+	// FormatUnix unix seconds
+	FormatUnix
+	// FormatUnixMilli unix milliseconds
+	FormatUnixMilli
+)
+
+// DefaultJSONFormat used by Carbon.MarshalJSON
+var DefaultJSONFormat = FormatObject
+
+// unixMilliThreshold numbers with greater magnitude are unmarshalled as milliseconds,
+// as seconds they would be beyond year 5000
+const unixMilliThreshold = 1e11
+
+// MarshalJSONFormat marshal carbon in the given format, invalid carbon is marshalled as null
+func (c Carbon) MarshalJSONFormat(format JSONFormat) ([]byte, error) {
+	if !c.Valid {
+		return []byte("null"), nil
+	}
+
+	switch format {
+	case FormatRFC3339:
+		return json.Marshal(c.t.Format(time.RFC3339))
+	case FormatDateTime:
+		return json.Marshal(c.GetDateTimeString())
+	case FormatUnix:
+		return []byte(strconv.FormatInt(c.t.Unix(), 10)), nil
+	case FormatUnixMilli:
+		return []byte(strconv.FormatInt(c.t.UnixMilli(), 10)), nil
+	}
+
+	year, month, day := c.t.Date()
+
+	return json.Marshal(map[string]interface{}{
+		"year":      year,
+		"month":     month,
+		"day":       day,
+		"hour":      c.t.Hour(),
+		"minute":    c.t.Minute(),
+		"second":    c.t.Second(),
+		"time":      c.t.UnixMilli(),
+		"timezone":  c.t.Location().String(),
+		"datetime":  c.GetDateTimeString(),
+		"timestamp": c.GetTimestamp(),
+	})
+}
+
+// UnmarshalJSON accept every JSONFormat: objects, strings parsed with ParseLoyouts,
+// unix seconds or milliseconds, null is unmarshalled as invalid carbon
+func (c *Carbon) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		*c = Carbon{}
+		return nil
+	}
+
+	switch data[0] {
+	case '"':
+		var s string
+		if e := json.Unmarshal(data, &s); e != nil {
+			return e
+		}
+		cb, e := c.getFactory().Parse(s, nil)
+		if e != nil {
+			return e
+		}
+		*c = cb
+		return nil
+	case '{':
+		return c.unmarshalObject(data)
+	}
+
+	n, e := strconv.ParseInt(string(data), 10, 64)
+	if e != nil {
+		return errors.New("carbon: can not unmarshal " + string(data))
+	}
+	*c = c.getFactory().fromEpoch(n)
+
+	return nil
+}
+
+func (c *Carbon) unmarshalObject(data []byte) error {
+	var obj struct {
+		Time      *int64 `json:"time"`
+		Timestamp *int64 `json:"timestamp"`
+		Timezone  string `json:"timezone"`
+		Datetime  string `json:"datetime"`
+	}
+	if e := json.Unmarshal(data, &obj); e != nil {
+		return e
+	}
+
+	var loc *time.Location
+	if obj.Timezone != "" {
+		loc, _ = time.LoadLocation(obj.Timezone)
+	}
+
+	f := c.getFactory()
+	switch {
+	case obj.Time != nil:
+		*c = f.New(time.UnixMilli(*obj.Time), loc)
+	case obj.Timestamp != nil:
+		*c = f.New(time.Unix(*obj.Timestamp, 0), loc)
+	case obj.Datetime != "":
+		cb, e := f.Parse(obj.Datetime, loc)
+		if e != nil {
+			return e
+		}
+		*c = cb
+	default:
+		return errors.New("carbon: missing time, timestamp or datetime in object")
+	}
+
+	return nil
+}
+
+// unmarshalEpoch unmarshal numbers in the given unit instead of guessing it, anything else like UnmarshalJSON
+func (c *Carbon) unmarshalEpoch(data []byte, milli bool) error {
+	n, e := strconv.ParseInt(string(bytes.TrimSpace(data)), 10, 64)
+	if e != nil {
+		return c.UnmarshalJSON(data)
+	}
+
+	if milli {
+		*c = c.getFactory().FromTimestampMilli(n)
+	} else {
+		*c = c.getFactory().FromTimestamp(n)
+	}
+
+	return nil
+}
+
+// RFC3339 carbon marshalled as FormatRFC3339 regardless of DefaultJSONFormat
+type RFC3339 struct {
+	Carbon
+}
+
+func (c RFC3339) MarshalJSON() ([]byte, error) {
+	return c.MarshalJSONFormat(FormatRFC3339)
+}
+
+// DateTime carbon marshalled as FormatDateTime regardless of DefaultJSONFormat
+type DateTime struct {
+	Carbon
+}
+
+func (c DateTime) MarshalJSON() ([]byte, error) {
+	return c.MarshalJSONFormat(FormatDateTime)
+}
+
+// Unix carbon marshalled as FormatUnix regardless of DefaultJSONFormat
+type Unix struct {
+	Carbon
+}
+
+func (c Unix) MarshalJSON() ([]byte, error) {
+	return c.MarshalJSONFormat(FormatUnix)
+}
+
+// UnmarshalJSON like Carbon.UnmarshalJSON but numbers are always unix seconds
+func (c *Unix) UnmarshalJSON(data []byte) error {
+	return c.Carbon.unmarshalEpoch(data, false)
+}
+
+// UnixMilli carbon marshalled as FormatUnixMilli regardless of DefaultJSONFormat
+type UnixMilli struct {
+	Carbon
+}
+
+func (c UnixMilli) MarshalJSON() ([]byte, error) {
+	return c.MarshalJSONFormat(FormatUnixMilli)
+}
+
+// UnmarshalJSON like Carbon.UnmarshalJSON but numbers are always unix milliseconds
+func (c *UnixMilli) UnmarshalJSON(data []byte) error {
+	return c.Carbon.unmarshalEpoch(data, true)
+}
+
+// Object carbon marshalled as FormatObject regardless of DefaultJSONFormat
+type Object struct {
+	Carbon
+}
+
+func (c Object) MarshalJSON() ([]byte, error) {
+	return c.MarshalJSONFormat(FormatObject)
+}
@@ -0,0 +1,167 @@
+package carbon
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locale translations used by IsoFormat, TranslatedFormat and DiffForHumans
+type Locale struct {
+	Months      [12]string
+	ShortMonths [12]string
+	// Weekdays, ShortWeekdays and MinWeekdays start with Sunday, like time.Weekday
+	Weekdays      [7]string
+	ShortWeekdays [7]string
+	MinWeekdays   [7]string
+	AM            string
+	PM            string
+	// Ordinal format n as an ordinal number of unit, e.g. "5th" or "5日"
+	Ordinal func(n int, unit Unit) string
+	// DateFormat and DateTimeFormat default formats in moment.js tokens
+	DateFormat     string
+	DateTimeFormat string
+	Human          HumanLocale
+}
+
+// DefaultLocale used when carbon has no locale set
+var DefaultLocale = "en"
+
+var (
+	locales = map[string]Locale{
+		"en": {
+			Months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+			ShortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+			Weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+			ShortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+			MinWeekdays:   [7]string{"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+			AM:            "AM",
+			PM:            "PM",
+			Ordinal: func(n int, _ Unit) string {
+				return ordinal(n)
+			},
+			DateFormat:     "dddd, MMMM Do YYYY",
+			DateTimeFormat: "dddd, MMMM Do YYYY h:mm A",
+		},
+		"zh-CN": {
+			Months:        [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+			ShortMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+			Weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+			ShortWeekdays: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+			MinWeekdays:   [7]string{"日", "一", "二", "三", "四", "五", "六"},
+			AM:            "上午",
+			PM:            "下午",
+			Ordinal: func(n int, unit Unit) string {
+				switch unit {
+				case Day:
+					return strconv.Itoa(n) + "日"
+				case Month:
+					return strconv.Itoa(n) + "月"
+				case Week:
+					return strconv.Itoa(n) + "周"
+				}
+				return "第" + strconv.Itoa(n)
+			},
+			DateFormat:     "YYYY年M月D日 dddd",
+			DateTimeFormat: "YYYY年M月D日 dddd HH:mm",
+		},
+	}
+	localeMu sync.RWMutex
+)
+
+// RegisterLocale register or replace a locale, the human part is also registered for DiffForHumans
+func RegisterLocale(name string, locale Locale) {
+	if locale.Ordinal == nil {
+		locale.Ordinal = func(n int, _ Unit) string {
+			return ordinal(n)
+		}
+	}
+
+	localeMu.Lock()
+	locales[name] = locale
+	localeMu.Unlock()
+
+	if locale.Human.Units != nil {
+		RegisterHumanLocale(name, locale.Human)
+	}
+}
+
+func GetLocale(name string) (Locale, bool) {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	l, ok := locales[name]
+	return l, ok
+}
+
+// Locale set the locale of carbon
+func (c Carbon) Locale(name string) Carbon {
+	c.locale = name
+	return c
+}
+
+// LocaleName get the locale of carbon, the factory locale if not set
+func (c Carbon) LocaleName() string {
+	if c.locale == "" {
+		return c.config().Locale
+	}
+
+	return c.locale
+}
+
+// IsoFormat format with moment.js tokens, translating names and ordinals to the carbon locale
+func (c Carbon) IsoFormat(format string) string {
+	l := c.getLocale()
+	return compileMoment(format).format(c.t, &l)
+}
+
+// TranslatedFormat format with PHP date() tokens, translating names and ordinals to the carbon locale
+func (c Carbon) TranslatedFormat(format string) string {
+	l := c.getLocale()
+	return compilePHP(format).format(c.t, &l)
+}
+
+// LocaleDateString format with the DateFormat of the carbon locale
+func (c Carbon) LocaleDateString() string {
+	return c.IsoFormat(c.getLocale().DateFormat)
+}
+
+// LocaleDateTimeString format with the DateTimeFormat of the carbon locale
+func (c Carbon) LocaleDateTimeString() string {
+	return c.IsoFormat(c.getLocale().DateTimeFormat)
+}
+
+func (c Carbon) getLocale() Locale {
+	if l, ok := GetLocale(c.LocaleName()); ok {
+		return l
+	}
+	l, _ := GetLocale("en")
+	return l
+}
+
+func translateMonth(l Locale, t time.Time) string {
+	return l.Months[t.Month()-1]
+}
+
+func translateShortMonth(l Locale, t time.Time) string {
+	return l.ShortMonths[t.Month()-1]
+}
+
+func translateWeekday(l Locale, t time.Time) string {
+	return l.Weekdays[t.Weekday()]
+}
+
+func translateShortWeekday(l Locale, t time.Time) string {
+	return l.ShortWeekdays[t.Weekday()]
+}
+
+func translateMeridiem(l Locale, t time.Time) string {
+	if t.Hour() < 12 {
+		return l.AM
+	}
+	return l.PM
+}
+
+func translateMeridiemLower(l Locale, t time.Time) string {
+	return strings.ToLower(translateMeridiem(l, t))
+}
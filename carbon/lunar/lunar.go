@@ -0,0 +1,255 @@
+// Package lunar converts carbon to and from the Chinese lunisolar calendar.
+//
+// Lunar years 1900 to 2100 are supported, from 1900-01-31 (lunar 1900-01-01)
+// to the end of lunar year 2100 in January 2101. Conversion uses the wall date
+// of the carbon in its own timezone, solar terms are computed for China Standard Time.
+package lunar
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/enorith/supports/carbon"
+)
+
+const (
+	minYear = 1900
+	maxYear = 2100
+)
+
+// ErrOutOfRange date outside the supported lunar years
+var ErrOutOfRange = errors.New("lunar: date out of range, lunar years 1900 to 2100 are supported")
+
+// yearInfo one entry per lunar year from 1900, bits 0-3 leap month (0 for none),
+// bits 4-15 months 12 down to 1 with 30 days if set or 29 days, bit 16 leap month with 30 days
+var yearInfo = [...]uint32{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, // 1900
+	0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, // 1910
+	0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, // 1920
+	0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, // 1930
+	0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, // 1940
+	0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5b0, 0x14573, 0x052b0, 0x0a9a8, 0x0e950, 0x06aa0, // 1950
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, // 1960
+	0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b6a0, 0x195a6, // 1970
+	0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, // 1980
+	0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, // 1990
+	0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, // 2000
+	0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, // 2010
+	0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, // 2020
+	0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, // 2030
+	0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, // 2040
+	0x14b63, 0x09370, 0x049f8, 0x04970, 0x064b0, 0x168a6, 0x0ea50, 0x06b20, 0x1a6c4, 0x0aae0, // 2050
+	0x092e0, 0x0d2e3, 0x0c960, 0x0d557, 0x0d4a0, 0x0da50, 0x05d55, 0x056a0, 0x0a6d0, 0x055d4, // 2060
+	0x052d0, 0x0a9b8, 0x0a950, 0x0b4a0, 0x0b6a6, 0x0ad50, 0x055a0, 0x0aba4, 0x0a5b0, 0x052b0, // 2070
+	0x0b273, 0x06930, 0x07337, 0x06aa0, 0x0ad50, 0x14b55, 0x04b60, 0x0a570, 0x054e4, 0x0d160, // 2080
+	0x0e968, 0x0d520, 0x0daa0, 0x16aa6, 0x056d0, 0x04ae0, 0x0a9d4, 0x0a2d0, 0x0d150, 0x0f252, // 2090
+	0x0d520, // 2100
+}
+
+// epoch solar date of lunar 1900-01-01
+var epoch = time.Date(1900, 1, 31, 0, 0, 0, 0, time.UTC)
+
+var (
+	stems      = []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+	branches   = []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+	zodiacs    = []string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+	monthNames = []string{"正", "二", "三", "四", "五", "六", "七", "八", "九", "十", "冬", "腊"}
+	digits     = []string{"十", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+)
+
+// Date lunar date, Month is 1 to 12 and Leap marks the leap month following Month
+type Date struct {
+	Year  int
+	Month int
+	Day   int
+	Leap  bool
+}
+
+// New validate a lunar date
+func New(year, month, day int, leap bool) (Date, error) {
+	if year < minYear || year > maxYear {
+		return Date{}, ErrOutOfRange
+	}
+	if month < 1 || month > 12 {
+		return Date{}, fmt.Errorf("lunar: invalid month %d", month)
+	}
+	if leap && LeapMonth(year) != month {
+		return Date{}, fmt.Errorf("lunar: year %d has no leap month %d", year, month)
+	}
+	if n := monthDays(year, month, leap); day < 1 || day > n {
+		return Date{}, fmt.Errorf("lunar: invalid day %d, month has %d days", day, n)
+	}
+
+	return Date{Year: year, Month: month, Day: day, Leap: leap}, nil
+}
+
+// MustNew like New but panics on error
+func MustNew(year, month, day int, leap bool) Date {
+	d, e := New(year, month, day, leap)
+	if e != nil {
+		panic(e)
+	}
+
+	return d
+}
+
+// FromCarbon lunar date of the wall date of c
+func FromCarbon(c carbon.Carbon) (Date, error) {
+	y, m, d := c.GetTime().Date()
+	offset := int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(epoch) / (24 * time.Hour))
+	if offset < 0 {
+		return Date{}, ErrOutOfRange
+	}
+
+	year := minYear
+	for ; year <= maxYear; year++ {
+		n := yearDays(year)
+		if offset < n {
+			break
+		}
+		offset -= n
+	}
+	if year > maxYear {
+		return Date{}, ErrOutOfRange
+	}
+
+	leapMonth := LeapMonth(year)
+	for month := 1; month <= 12; month++ {
+		for _, leap := range []bool{false, true} {
+			if leap && month != leapMonth {
+				continue
+			}
+			n := monthDays(year, month, leap)
+			if offset < n {
+				return Date{Year: year, Month: month, Day: offset + 1, Leap: leap}, nil
+			}
+			offset -= n
+		}
+	}
+
+	// unreachable, offset is less than the days of the year
+	return Date{}, ErrOutOfRange
+}
+
+// Carbon start of the solar day of the lunar date, in tz or carbon.Timezone if omitted
+func (d Date) Carbon(tz ...*time.Location) (carbon.Carbon, error) {
+	if _, e := New(d.Year, d.Month, d.Day, d.Leap); e != nil {
+		return carbon.Carbon{}, e
+	}
+
+	offset := d.Day - 1
+	for y := minYear; y < d.Year; y++ {
+		offset += yearDays(y)
+	}
+	leapMonth := LeapMonth(d.Year)
+	for m := 1; m < d.Month; m++ {
+		offset += monthDays(d.Year, m, false)
+		if m == leapMonth {
+			offset += monthDays(d.Year, m, true)
+		}
+	}
+	if d.Leap {
+		offset += monthDays(d.Year, d.Month, false)
+	}
+
+	t := epoch.AddDate(0, 0, offset)
+	return carbon.FromDate(t.Year(), t.Month(), t.Day(), tz...), nil
+}
+
+// GanZhi stem-branch name of the lunar year, e.g. "甲辰"
+func (d Date) GanZhi() string {
+	return stems[mod(d.Year-4, 10)] + branches[mod(d.Year-4, 12)]
+}
+
+// Zodiac animal of the lunar year, e.g. "龙"
+func (d Date) Zodiac() string {
+	return zodiacs[mod(d.Year-4, 12)]
+}
+
+// MonthName chinese month name, e.g. "正月" or "闰四月"
+func (d Date) MonthName() string {
+	name := monthNames[d.Month-1] + "月"
+	if d.Leap {
+		return "闰" + name
+	}
+
+	return name
+}
+
+// DayName chinese day name, e.g. "初五", "廿一" or "三十"
+func (d Date) DayName() string {
+	switch {
+	case d.Day == 10:
+		return "初十"
+	case d.Day == 20:
+		return "二十"
+	case d.Day == 30:
+		return "三十"
+	case d.Day < 10:
+		return "初" + digits[d.Day]
+	case d.Day < 20:
+		return "十" + digits[d.Day-10]
+	default:
+		return "廿" + digits[d.Day-20]
+	}
+}
+
+// String formatted like "甲辰年 三月初五"
+func (d Date) String() string {
+	return d.GanZhi() + "年 " + d.MonthName() + d.DayName()
+}
+
+// Format replace "{year}", "{ganzhi}", "{zodiac}", "{month}" and "{day}" in layout,
+// e.g. Format("{zodiac}年{month}{day}") gives "龙年三月初五"
+func (d Date) Format(layout string) string {
+	return strings.NewReplacer(
+		"{year}", fmt.Sprint(d.Year),
+		"{ganzhi}", d.GanZhi(),
+		"{zodiac}", d.Zodiac(),
+		"{month}", d.MonthName(),
+		"{day}", d.DayName(),
+	).Replace(layout)
+}
+
+// LeapMonth leap month of the lunar year, 0 if it has none or is out of range
+func LeapMonth(year int) int {
+	if year < minYear || year > maxYear {
+		return 0
+	}
+
+	return int(yearInfo[year-minYear] & 0xf)
+}
+
+// monthDays days of the month, or of its leap month
+func monthDays(year, month int, leap bool) int {
+	info := yearInfo[year-minYear]
+	if leap {
+		if info&0x10000 != 0 {
+			return 30
+		}
+		return 29
+	}
+	if info&(0x10000>>uint(month)) != 0 {
+		return 30
+	}
+
+	return 29
+}
+
+func yearDays(year int) int {
+	days := 0
+	for m := 1; m <= 12; m++ {
+		days += monthDays(year, m, false)
+	}
+	if leap := LeapMonth(year); leap > 0 {
+		days += monthDays(year, leap, true)
+	}
+
+	return days
+}
+
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
@@ -0,0 +1,194 @@
+package lunar_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/enorith/supports/carbon"
+	"github.com/enorith/supports/carbon/lunar"
+)
+
+func date(y int, m time.Month, d int) carbon.Carbon {
+	return carbon.FromDate(y, m, d, time.UTC)
+}
+
+func TestFromCarbon(t *testing.T) {
+	cases := []struct {
+		solar  carbon.Carbon
+		want   lunar.Date
+		format string
+	}{
+		{date(1900, 1, 31), lunar.Date{Year: 1900, Month: 1, Day: 1}, "庚子年 正月初一"},
+		{date(1949, 10, 1), lunar.Date{Year: 1949, Month: 8, Day: 10}, "己丑年 八月初十"},
+		{date(2000, 2, 5), lunar.Date{Year: 2000, Month: 1, Day: 1}, "庚辰年 正月初一"},
+		{date(2020, 5, 23), lunar.Date{Year: 2020, Month: 4, Day: 1, Leap: true}, "庚子年 闰四月初一"},
+		{date(2023, 3, 22), lunar.Date{Year: 2023, Month: 2, Day: 1, Leap: true}, "癸卯年 闰二月初一"},
+		{date(2024, 2, 9), lunar.Date{Year: 2023, Month: 12, Day: 30}, "癸卯年 腊月三十"},
+		{date(2024, 2, 10), lunar.Date{Year: 2024, Month: 1, Day: 1}, "甲辰年 正月初一"},
+		{date(2024, 4, 13), lunar.Date{Year: 2024, Month: 3, Day: 5}, "甲辰年 三月初五"},
+		{date(2024, 9, 17), lunar.Date{Year: 2024, Month: 8, Day: 15}, "甲辰年 八月十五"},
+		{date(2025, 1, 29), lunar.Date{Year: 2025, Month: 1, Day: 1}, "乙巳年 正月初一"},
+		{date(2025, 7, 25), lunar.Date{Year: 2025, Month: 6, Day: 1, Leap: true}, "乙巳年 闰六月初一"},
+		{date(2033, 12, 22), lunar.Date{Year: 2033, Month: 11, Day: 1, Leap: true}, "癸丑年 闰冬月初一"},
+		{date(2100, 2, 9), lunar.Date{Year: 2100, Month: 1, Day: 1}, "庚申年 正月初一"},
+	}
+
+	for _, cs := range cases {
+		got, e := lunar.FromCarbon(cs.solar)
+		if e != nil {
+			t.Errorf("%s: %v", cs.solar.GetDateString(), e)
+			continue
+		}
+		if got != cs.want {
+			t.Errorf("%s: expected %+v, got %+v", cs.solar.GetDateString(), cs.want, got)
+		}
+		if got.String() != cs.format {
+			t.Errorf("%s: expected %s, got %s", cs.solar.GetDateString(), cs.format, got)
+		}
+
+		back, e := cs.want.Carbon(time.UTC)
+		if e != nil || back.GetDateString() != cs.solar.GetDateString() {
+			t.Errorf("%+v: expected %s, got %s, %v", cs.want, cs.solar.GetDateString(), back.GetDateString(), e)
+		}
+	}
+}
+
+func TestNewYear(t *testing.T) {
+	cases := map[int]string{
+		1901: "1901-02-19", 1912: "1912-02-18", 1930: "1930-01-30", 1949: "1949-01-29", 1976: "1976-01-31",
+		1984: "1984-02-02", 1990: "1990-01-27", 2008: "2008-02-07", 2012: "2012-01-23", 2017: "2017-01-28",
+		2021: "2021-02-12", 2022: "2022-02-01", 2023: "2023-01-22", 2026: "2026-02-17", 2050: "2050-01-23",
+	}
+
+	for year, want := range cases {
+		c, e := lunar.MustNew(year, 1, 1, false).Carbon(time.UTC)
+		if e != nil || c.GetDateString() != want {
+			t.Errorf("%d: expected %s, got %s, %v", year, want, c.GetDateString(), e)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	last := lunar.Date{}
+	for c := date(1900, 1, 31); c.Lt(date(2101, 1, 1)); c = c.AddDay() {
+		d, e := lunar.FromCarbon(c)
+		if e != nil {
+			t.Fatalf("%s: %v", c.GetDateString(), e)
+		}
+		back, e := d.Carbon(time.UTC)
+		if e != nil || !back.Eq(c) {
+			t.Fatalf("%s: round trip gave %s, %v", c.GetDateString(), back.GetDateString(), e)
+		}
+		if d.Day != 1 && d.Day != last.Day+1 {
+			t.Fatalf("%s: %+v does not follow %+v", c.GetDateString(), d, last)
+		}
+		last = d
+	}
+}
+
+func TestYearNames(t *testing.T) {
+	cases := []struct {
+		year   int
+		ganzhi string
+		zodiac string
+	}{
+		{1900, "庚子", "鼠"},
+		{1984, "甲子", "鼠"},
+		{2000, "庚辰", "龙"},
+		{2023, "癸卯", "兔"},
+		{2024, "甲辰", "龙"},
+		{2025, "乙巳", "蛇"},
+		{2043, "癸亥", "猪"},
+	}
+
+	for _, cs := range cases {
+		d := lunar.MustNew(cs.year, 1, 1, false)
+		if d.GanZhi() != cs.ganzhi || d.Zodiac() != cs.zodiac {
+			t.Errorf("%d: expected %s %s, got %s %s", cs.year, cs.ganzhi, cs.zodiac, d.GanZhi(), d.Zodiac())
+		}
+	}
+
+	d := lunar.MustNew(2024, 3, 21, false)
+	if got := d.Format("{zodiac}年{month}{day} ({year})"); got != "龙年三月廿一 (2024)" {
+		t.Errorf("unexpected format %s", got)
+	}
+}
+
+func TestNew(t *testing.T) {
+	cases := []struct {
+		year, month, day int
+		leap             bool
+	}{
+		{1899, 1, 1, false},
+		{2101, 1, 1, false},
+		{2024, 13, 1, false},
+		{2024, 4, 1, true},
+		{2024, 1, 0, false},
+		{2024, 1, 31, false},
+		{2023, 2, 30, true},
+	}
+
+	for _, cs := range cases {
+		if _, e := lunar.New(cs.year, cs.month, cs.day, cs.leap); e == nil {
+			t.Errorf("%+v: expected error", cs)
+		}
+	}
+
+	if _, e := lunar.FromCarbon(date(1900, 1, 30)); !errors.Is(e, lunar.ErrOutOfRange) {
+		t.Errorf("expected out of range, got %v", e)
+	}
+	if _, e := lunar.FromCarbon(date(2101, 1, 29)); !errors.Is(e, lunar.ErrOutOfRange) {
+		t.Errorf("expected out of range, got %v", e)
+	}
+	if lunar.LeapMonth(2023) != 2 || lunar.LeapMonth(2024) != 0 {
+		t.Error("unexpected leap months")
+	}
+}
+
+func TestSolarTerms(t *testing.T) {
+	cases := []struct {
+		year int
+		name string
+		want string
+	}{
+		{2000, "春分", "2000-03-20 15:35"},
+		{2021, "冬至", "2021-12-21 23:59"},
+		{2023, "冬至", "2023-12-22 11:27"},
+		{2024, "立春", "2024-02-04 16:27"},
+		{2024, "春分", "2024-03-20 11:06"},
+		{2024, "夏至", "2024-06-21 04:51"},
+		{2024, "冬至", "2024-12-21 17:20"},
+		{2025, "立春", "2025-02-03 22:10"},
+		{2025, "春分", "2025-03-20 17:01"},
+	}
+
+	for _, cs := range cases {
+		var got string
+		for _, term := range lunar.SolarTerms(cs.year) {
+			if term.Name == cs.name {
+				got = term.Time.Format("2006-01-02 15:04")
+			}
+		}
+		if got != cs.want {
+			t.Errorf("%d %s: expected %s, got %s", cs.year, cs.name, cs.want, got)
+		}
+	}
+
+	terms := lunar.SolarTerms(2024)
+	if len(terms) != 24 {
+		t.Fatalf("expected 24 terms, got %d", len(terms))
+	}
+	for i := 1; i < len(terms); i++ {
+		if !terms[i].Time.Gt(terms[i-1].Time) {
+			t.Errorf("expected %s after %s", terms[i].Name, terms[i-1].Name)
+		}
+	}
+
+	if term, ok := lunar.SolarTerm(date(2024, 4, 4)); !ok || term.Name != "清明" {
+		t.Errorf("expected 清明, got %v %v", term.Name, ok)
+	}
+	if _, ok := lunar.SolarTerm(date(2024, 4, 5)); ok {
+		t.Error("expected no solar term")
+	}
+}
@@ -0,0 +1,183 @@
+package lunar
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/enorith/supports/carbon"
+)
+
+// TermNames the 24 solar terms in calendar order, starting with 小寒 in early January
+var TermNames = []string{
+	"小寒", "大寒", "立春", "雨水", "惊蛰", "春分", "清明", "谷雨", "立夏", "小满", "芒种", "夏至",
+	"小暑", "大暑", "立秋", "处暑", "白露", "秋分", "寒露", "霜降", "立冬", "小雪", "大雪", "冬至",
+}
+
+// ChinaStandardTime timezone of solar term dates
+var ChinaStandardTime = time.FixedZone("CST", 8*3600)
+
+// Term solar term and the instant the sun reaches its longitude, in ChinaStandardTime
+type Term struct {
+	Name string
+	Time carbon.Carbon
+}
+
+var termCache sync.Map
+
+// SolarTerms the 24 solar terms of the gregorian year, computed from the apparent solar longitude,
+// accurate to about a minute, so a term within a minute of midnight may fall on an adjacent day
+func SolarTerms(year int) []Term {
+	if terms, ok := termCache.Load(year); ok {
+		return append([]Term(nil), terms.([]Term)...)
+	}
+
+	terms := make([]Term, len(TermNames))
+	for i, name := range TermNames {
+		// 小寒 is at 285 degrees, each term adds 15
+		longitude := math.Mod(285+float64(i)*15, 360)
+		terms[i] = Term{Name: name, Time: carbon.New(solarLongitudeTime(year, longitude), ChinaStandardTime)}
+	}
+	termCache.Store(year, terms)
+
+	return append([]Term(nil), terms...)
+}
+
+// SolarTerm solar term on the wall date of c, false if there is none
+func SolarTerm(c carbon.Carbon) (Term, bool) {
+	y, m, d := c.GetTime().Date()
+	for _, term := range SolarTerms(y) {
+		ty, tm, td := term.Time.GetTime().Date()
+		if ty == y && tm == m && td == d {
+			return term, true
+		}
+	}
+
+	return Term{}, false
+}
+
+// solarLongitudeTime instant in year when the apparent solar longitude reaches longitude degrees
+func solarLongitudeTime(year int, longitude float64) time.Time {
+	// degrees after the March equinox, terms of early January are before it
+	after := longitude
+	if after >= 285 {
+		after -= 360
+	}
+	jd := julianDay(time.Date(year, 3, 20, 12, 0, 0, 0, time.UTC)) + after/360*tropicalYear
+
+	for i := 0; i < 10; i++ {
+		diff := math.Mod(longitude-apparentLongitude(jd)+540, 360) - 180
+		jd += diff / 360 * tropicalYear
+		if math.Abs(diff) < 1e-7 {
+			break
+		}
+	}
+
+	// julian day is in terrestrial time, convert to universal time
+	unix := (jd-2440587.5)*86400 - deltaT(year)
+	sec := math.Floor(unix)
+	return time.Unix(int64(sec), int64((unix-sec)*1e9)).Round(time.Second)
+}
+
+const tropicalYear = 365.2422
+
+func julianDay(t time.Time) float64 {
+	return float64(t.Unix())/86400 + 2440587.5
+}
+
+// apparentLongitude apparent geocentric longitude of the sun in degrees at the julian ephemeris day,
+// from the truncated VSOP87 series in Meeus, Astronomical Algorithms, chapters 25 and 32
+func apparentLongitude(jde float64) float64 {
+	tau := (jde - 2451545) / 365250
+	var l, pow float64 = 0, 1
+	for _, series := range earthLongitude {
+		var sum float64
+		for _, term := range series {
+			sum += term[0] * math.Cos(term[1]+term[2]*tau)
+		}
+		l += sum * pow
+		pow *= tau
+	}
+	// heliocentric earth to geocentric sun, FK5 frame correction
+	lon := math.Mod(l/1e8*180/math.Pi+180, 360) - 0.09033/3600
+
+	t := tau * 10
+	omega := rad(125.04452 - 1934.136261*t)
+	sun := rad(280.4665 + 36000.7698*t)
+	moon := rad(218.3165 + 481267.8813*t)
+	nutation := -17.20*math.Sin(omega) - 1.32*math.Sin(2*sun) - 0.23*math.Sin(2*moon) + 0.21*math.Sin(2*omega)
+	// aberration with the mean earth-sun distance
+	aberration := -20.4898
+
+	return lon + (nutation+aberration)/3600
+}
+
+// earthLongitude VSOP87 heliocentric longitude series L0 to L5 of the earth, amplitude 1e-8 radian, phase, frequency
+var earthLongitude = [][][3]float64{
+	{
+		{175347046, 0, 0}, {3341656, 4.6692568, 6283.0758500}, {34894, 4.62610, 12566.15170}, {3497, 2.7441, 5753.3849},
+		{3418, 2.8289, 3.5231}, {3136, 3.6277, 77713.7715}, {2676, 4.4181, 7860.4194}, {2343, 6.1352, 3930.2097},
+		{1324, 0.7425, 11506.7698}, {1273, 2.0371, 529.6910}, {1199, 1.1096, 1577.3435}, {990, 5.233, 5884.927},
+		{902, 2.045, 26.298}, {857, 3.508, 398.149}, {780, 1.179, 5223.694}, {753, 2.533, 5507.553},
+		{505, 4.583, 18849.228}, {492, 4.205, 775.523}, {357, 2.920, 0.067}, {317, 5.849, 11790.629},
+		{284, 1.899, 796.298}, {271, 0.315, 10977.079}, {243, 0.345, 5486.778}, {206, 4.806, 2544.314},
+		{205, 1.869, 5573.143}, {202, 2.458, 6069.777}, {156, 0.833, 213.299}, {132, 3.411, 2942.463},
+		{126, 1.083, 20.775}, {115, 0.645, 0.980}, {103, 0.636, 4694.003}, {102, 0.976, 15720.839},
+		{102, 4.267, 7.114}, {99, 6.21, 2146.17}, {98, 0.68, 155.42}, {86, 5.98, 161000.69},
+		{85, 1.30, 6275.96}, {85, 3.67, 71430.70}, {80, 1.81, 17260.15}, {79, 3.04, 12036.46},
+		{75, 1.76, 5088.63}, {74, 3.50, 3154.69}, {74, 4.68, 801.82}, {70, 0.83, 9437.76},
+		{62, 3.98, 8827.39}, {61, 1.82, 7084.90}, {57, 2.78, 6286.60}, {56, 4.39, 14143.50},
+		{56, 3.47, 6279.55}, {52, 0.19, 12139.55}, {52, 1.33, 1748.02}, {51, 0.28, 5856.48},
+		{49, 0.49, 1194.45}, {41, 5.37, 8429.24}, {41, 2.40, 19651.05}, {39, 6.17, 10447.39},
+		{37, 6.04, 10213.29}, {37, 2.57, 1059.38}, {36, 1.71, 2352.87}, {36, 1.78, 6812.77},
+		{33, 0.59, 17789.85}, {30, 0.44, 83996.85}, {30, 2.74, 1349.87}, {25, 3.16, 4690.48},
+	},
+	{
+		{628331966747, 0, 0}, {206059, 2.678235, 6283.075850}, {4303, 2.6351, 12566.1517}, {425, 1.590, 3.523},
+		{119, 5.796, 26.298}, {109, 2.966, 1577.344}, {93, 2.59, 18849.23}, {72, 1.14, 529.69},
+		{68, 1.87, 398.15}, {67, 4.41, 5507.55}, {59, 2.89, 5223.69}, {56, 2.17, 155.42},
+		{45, 0.40, 796.30}, {36, 0.47, 775.52}, {29, 2.65, 7.11}, {21, 5.34, 0.98},
+		{19, 1.85, 5486.78}, {19, 4.97, 213.30}, {17, 2.99, 6275.96}, {16, 0.03, 2544.31},
+		{16, 1.43, 2146.17}, {15, 1.21, 10977.08}, {12, 2.83, 1748.02}, {12, 3.26, 5088.63},
+		{12, 5.27, 1194.45}, {12, 2.08, 4694.00}, {11, 0.77, 553.57}, {10, 1.30, 6286.60},
+		{10, 4.24, 1349.87}, {9, 2.70, 242.73}, {9, 5.64, 951.72}, {8, 5.30, 2352.87},
+		{6, 2.65, 9437.76}, {6, 4.67, 4690.48},
+	},
+	{
+		{52919, 0, 0}, {8720, 1.0721, 6283.0758}, {309, 0.867, 12566.152}, {27, 0.05, 3.52},
+		{16, 5.19, 26.30}, {16, 3.68, 155.42}, {10, 0.76, 18849.23}, {9, 2.06, 77713.77},
+		{7, 0.83, 775.52}, {5, 4.66, 1577.34}, {4, 1.03, 7.11}, {4, 3.44, 5573.14},
+		{3, 5.14, 796.30}, {3, 6.05, 5507.55}, {3, 1.19, 242.73}, {3, 6.12, 529.69},
+		{3, 0.31, 398.15}, {3, 2.28, 553.57}, {2, 4.38, 5223.69}, {2, 3.75, 0.98},
+	},
+	{
+		{289, 5.844, 6283.076}, {35, 0, 0}, {17, 5.49, 12566.15}, {3, 5.20, 155.42},
+		{1, 4.72, 3.52}, {1, 5.30, 18849.23}, {1, 5.97, 242.73},
+	},
+	{
+		{114, 3.142, 0}, {8, 4.13, 6283.08}, {1, 3.84, 12566.15},
+	},
+	{
+		{1, 3.14, 0},
+	},
+}
+
+// deltaT difference between terrestrial and universal time in seconds,
+// interpolated from observed values and extrapolated after 2020
+func deltaT(year int) float64 {
+	table := []float64{-2.7, 10.5, 21.2, 24.0, 24.3, 29.1, 33.1, 40.2, 50.5, 56.9, 63.8, 66.1, 69.4, 75, 80, 86, 93, 102, 112, 123, 135, 148}
+	i := float64(year-1900) / 10
+	switch {
+	case i <= 0:
+		return table[0]
+	case i >= float64(len(table)-1):
+		return table[len(table)-1]
+	}
+
+	lo := int(i)
+	return table[lo] + (table[lo+1]-table[lo])*(i-float64(lo))
+}
+
+func rad(deg float64) float64 {
+	return deg * math.Pi / 180
+}
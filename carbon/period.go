@@ -0,0 +1,147 @@
+package carbon
+
+import "time"
+
+// Period iterable range of carbon between start and end, stepping one day by default
+type Period struct {
+	start        Carbon
+	end          Carbon
+	step         func(start Carbon, n int) Carbon
+	excludeStart bool
+	excludeEnd   bool
+	filters      []func(Carbon) bool
+}
+
+func NewPeriod(start, end Carbon) Period {
+	return Period{start: start, end: end}.EveryDays(1)
+}
+
+func (p Period) Start() Carbon {
+	return p.start
+}
+
+func (p Period) End() Carbon {
+	return p.end
+}
+
+// EveryDays step by calendar days
+func (p Period) EveryDays(days int) Period {
+	p.step = func(start Carbon, n int) Carbon {
+		return start.AddDays(n * days)
+	}
+	return p
+}
+
+func (p Period) EveryWeeks(weeks int) Period {
+	return p.EveryDays(7 * weeks)
+}
+
+// EveryMonths step by calendar months, days are clamped to the end of month
+func (p Period) EveryMonths(months int) Period {
+	p.step = func(start Carbon, n int) Carbon {
+		return start.AddMonthsNoOverflow(n * months)
+	}
+	return p
+}
+
+func (p Period) EveryYears(years int) Period {
+	return p.EveryMonths(12 * years)
+}
+
+// Every step by a fixed duration
+func (p Period) Every(d time.Duration) Period {
+	p.step = func(start Carbon, n int) Carbon {
+		return start.Add(time.Duration(n) * d)
+	}
+	return p
+}
+
+// AlignToWeek move the start to the start of its week, honoring WeekStartDay
+func (p Period) AlignToWeek() Period {
+	p.start = p.start.StartOfWeek()
+	return p
+}
+
+func (p Period) ExcludeStart() Period {
+	p.excludeStart = true
+	return p
+}
+
+func (p Period) ExcludeEnd() Period {
+	p.excludeEnd = true
+	return p
+}
+
+// Filter only keep values matching fn, multiple filters must all match
+func (p Period) Filter(fn func(Carbon) bool) Period {
+	filters := make([]func(Carbon) bool, len(p.filters), len(p.filters)+1)
+	copy(filters, p.filters)
+	p.filters = append(filters, fn)
+	return p
+}
+
+// OnWeekdays only keep values on the given weekdays
+func (p Period) OnWeekdays(days ...time.Weekday) Period {
+	return p.Filter(func(c Carbon) bool {
+		wd := c.t.Weekday()
+		for _, d := range days {
+			if d == wd {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// All iterate the period, compatible with go 1.23 range-over-func (iter.Seq[Carbon])
+func (p Period) All() func(yield func(Carbon) bool) {
+	// the zero Period has no step yet
+	if p.step == nil {
+		p = p.EveryDays(1)
+	}
+
+	return func(yield func(Carbon) bool) {
+		var prev Carbon
+		for i := 0; ; i++ {
+			c := p.step(p.start, i)
+			if c.Gt(p.end) || (p.excludeEnd && c.Eq(p.end)) {
+				return
+			}
+			// a non-positive step would never reach the end
+			if i > 0 && !c.Gt(prev) {
+				return
+			}
+			prev = c
+
+			if i == 0 && p.excludeStart {
+				continue
+			}
+			if !p.accept(c) {
+				continue
+			}
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+func (p Period) ToSlice() []Carbon {
+	var items []Carbon
+	p.All()(func(c Carbon) bool {
+		items = append(items, c)
+		return true
+	})
+
+	return items
+}
+
+func (p Period) accept(c Carbon) bool {
+	for _, fn := range p.filters {
+		if !fn(c) {
+			return false
+		}
+	}
+
+	return true
+}
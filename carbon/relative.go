@@ -0,0 +1,371 @@
+package carbon
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	relativeUnits = map[string]Unit{
+		"sec": Second, "secs": Second, "second": Second, "seconds": Second,
+		"min": Minute, "mins": Minute, "minute": Minute, "minutes": Minute,
+		"hour": Hour, "hours": Hour,
+		"day": Day, "days": Day,
+		"week": Week, "weeks": Week,
+		"month": Month, "months": Month,
+		"year": Year, "years": Year,
+	}
+	relativeWeekdays = map[string]time.Weekday{
+		"sunday": time.Sunday, "sun": time.Sunday,
+		"monday": time.Monday, "mon": time.Monday,
+		"tuesday": time.Tuesday, "tue": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday,
+		"thursday": time.Thursday, "thu": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday,
+		"saturday": time.Saturday, "sat": time.Saturday,
+	}
+	relativeMonths = map[string]time.Month{
+		"january": time.January, "jan": time.January,
+		"february": time.February, "feb": time.February,
+		"march": time.March, "mar": time.March,
+		"april": time.April, "apr": time.April,
+		"may":  time.May,
+		"june": time.June, "jun": time.June,
+		"july": time.July, "jul": time.July,
+		"august": time.August, "aug": time.August,
+		"september": time.September, "sep": time.September,
+		"october": time.October, "oct": time.October,
+		"november": time.November, "nov": time.November,
+		"december": time.December, "dec": time.December,
+	}
+	relativeModifiers = map[string]int{
+		"this":     0,
+		"next":     1,
+		"last":     -1,
+		"previous": -1,
+	}
+
+	relativeClockRe  = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(?::(\d{2}))?(am|pm)?$`)
+	relativeOffsetRe = regexp.MustCompile(`^([+-]?\d+)([a-z]+)$`)
+	relativeDateRe   = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+// ParseRelative parse an english relative expression against base,
+// a zero base is replaced with Now() in the package Timezone.
+//
+// Supported grammar, case-insensitive, modifiers are applied left to right,
+// the time of day is applied last so "9am tomorrow" equals "tomorrow 9am":
+//
+//	now                        no change
+//	today, midnight            start of day
+//	tomorrow, yesterday        start of next/previous day
+//	noon                       12:00:00
+//	[+|-]N <unit>              offset: "+3 days", "-2weeks"
+//	ago                        negate the preceding offsets: "1 year 2 months ago"
+//	next|last|this <unit>      offset by one unit: "next month", "last year"
+//	[next|last|this] <weekday> weekday navigation at start of day, "monday" and
+//	                           "this monday" include today, "next"/"last" exclude it
+//	<weekday> next|last|this week
+//	                           the weekday within that week, honoring WeekStartDay
+//	first|last day of          first or last day of the resulting month: "last day of next month"
+//	<month> [YYYY]             set month and optional year: "first day of march 2025"
+//	YYYY-MM-DD                 set date
+//	9am, 9:30pm, 21:00[:05]    set time, "9 am" and "at 9am" are also accepted
+//
+// Units are sec, min, hour, day, week, month, year with plural and abbreviated forms.
+func ParseRelative(expr string, base Carbon) (Carbon, error) {
+	if base.t.IsZero() {
+		base = Now()
+	}
+
+	tokens := tokenizeRelative(expr)
+	if len(tokens) == 0 {
+		return Carbon{}, fmt.Errorf("carbon: can not parse relative expression %q: empty expression", expr)
+	}
+
+	p := &relativeParser{tokens: tokens, base: base, t: base.t}
+	if e := p.parse(); e != nil {
+		return Carbon{}, fmt.Errorf("carbon: can not parse relative expression %q: %w", expr, e)
+	}
+
+	base.t = p.t
+	base.Valid = true
+	return base, nil
+}
+
+type relativeParser struct {
+	tokens []string
+	// base carries the factory used for week and day boundaries
+	base      Carbon
+	pos       int
+	t         time.Time
+	clock     []int
+	resetTime bool
+	// pending offsets, "ago" negates all of them
+	pending []relativeOffset
+	// dayOf 1 for "first day of", -1 for "last day of"
+	dayOf int
+}
+
+type relativeOffset struct {
+	unit Unit
+	n    int
+}
+
+func tokenizeRelative(expr string) []string {
+	var tokens []string
+	for _, f := range strings.Fields(strings.ToLower(strings.ReplaceAll(expr, ",", " "))) {
+		if m := relativeOffsetRe.FindStringSubmatch(f); m != nil {
+			if _, ok := relativeUnits[m[2]]; ok {
+				tokens = append(tokens, m[1], m[2])
+				continue
+			}
+		}
+		tokens = append(tokens, f)
+	}
+
+	return tokens
+}
+
+func (p *relativeParser) peek(offset int) string {
+	if i := p.pos + offset; i < len(p.tokens) {
+		return p.tokens[i]
+	}
+
+	return ""
+}
+
+func (p *relativeParser) parse() error {
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		p.pos++
+
+		if tok == "ago" {
+			if len(p.pending) == 0 {
+				return fmt.Errorf("unexpected %q without an offset", tok)
+			}
+			for i := range p.pending {
+				p.pending[i].n = -p.pending[i].n
+			}
+			continue
+		}
+		if u, n, ok := p.parseOffset(tok); ok {
+			p.pending = append(p.pending, relativeOffset{u, n})
+			continue
+		}
+		p.flush()
+
+		switch tok {
+		case "now", "at":
+			continue
+		case "today", "midnight":
+			p.resetTime = true
+			continue
+		case "noon":
+			p.clock = []int{12, 0, 0}
+			continue
+		case "tomorrow":
+			p.t = p.t.AddDate(0, 0, 1)
+			p.resetTime = true
+			continue
+		case "yesterday":
+			p.t = p.t.AddDate(0, 0, -1)
+			p.resetTime = true
+			continue
+		}
+
+		if (tok == "first" || tok == "last") && p.peek(0) == "day" && p.peek(1) == "of" {
+			p.pos += 2
+			p.dayOf = 1
+			if tok == "last" {
+				p.dayOf = -1
+			}
+			y, m, _ := p.t.Date()
+			p.t = time.Date(y, m, 1, p.t.Hour(), p.t.Minute(), p.t.Second(), p.t.Nanosecond(), p.t.Location())
+			continue
+		}
+
+		if n, ok := relativeModifiers[tok]; ok {
+			next := p.peek(0)
+			if wd, ok := relativeWeekdays[next]; ok {
+				p.pos++
+				p.t = relativeWeekday(p.t, wd, n)
+				p.resetTime = true
+				continue
+			}
+			return fmt.Errorf("unexpected %q after %q", next, tok)
+		}
+
+		if wd, ok := relativeWeekdays[tok]; ok {
+			if n, ok := relativeModifiers[p.peek(0)]; ok && p.peek(1) == "week" {
+				p.pos += 2
+				start := p.carbon().AddWeeks(n).StartOfWeek().t
+				offset := (int(wd) - int(start.Weekday()) + 7) % 7
+				p.t = start.AddDate(0, 0, offset)
+			} else {
+				p.t = relativeWeekday(p.t, wd, 0)
+			}
+			p.resetTime = true
+			continue
+		}
+
+		if m, ok := relativeMonths[tok]; ok {
+			year := p.t.Year()
+			if y, e := strconv.Atoi(p.peek(0)); e == nil && len(p.peek(0)) == 4 {
+				p.pos++
+				year = y
+			}
+			day := p.t.Day()
+			if last := daysInMonth(year, m); day > last {
+				day = last
+			}
+			p.t = time.Date(year, m, day, p.t.Hour(), p.t.Minute(), p.t.Second(), p.t.Nanosecond(), p.t.Location())
+			continue
+		}
+
+		if relativeDateRe.MatchString(tok) {
+			d, e := time.Parse("2006-01-02", tok)
+			if e != nil {
+				return e
+			}
+			p.t = time.Date(d.Year(), d.Month(), d.Day(), p.t.Hour(), p.t.Minute(), p.t.Second(), p.t.Nanosecond(), p.t.Location())
+			continue
+		}
+
+		if handled, e := p.parseClock(tok); handled || e != nil {
+			if e != nil {
+				return e
+			}
+			continue
+		}
+
+		if _, e := strconv.Atoi(tok); e == nil {
+			return fmt.Errorf("expected unit after %q, got %q", tok, p.peek(0))
+		}
+
+		return fmt.Errorf("unexpected %q", tok)
+	}
+	p.flush()
+
+	if p.dayOf == -1 {
+		p.t = p.t.AddDate(0, 1, -1)
+	}
+	if p.clock != nil {
+		y, m, d := p.t.Date()
+		p.t = time.Date(y, m, d, p.clock[0], p.clock[1], p.clock[2], 0, p.t.Location())
+	} else if p.resetTime {
+		p.t = p.carbon().StartOfDay().t
+	}
+
+	return nil
+}
+
+// parseOffset parse "N <unit>" or "next|last|this <unit>"
+func (p *relativeParser) parseOffset(tok string) (Unit, int, bool) {
+	u, ok := relativeUnits[p.peek(0)]
+	// "last day of" is not an offset
+	if !ok || p.peek(1) == "of" {
+		return 0, 0, false
+	}
+	n, ok := relativeModifiers[tok]
+	if !ok {
+		i, e := strconv.Atoi(tok)
+		if e != nil {
+			return 0, 0, false
+		}
+		n = i
+	}
+	p.pos++
+
+	return u, n, true
+}
+
+// carbon the current time with the properties of base
+func (p *relativeParser) carbon() Carbon {
+	c := p.base
+	c.t = p.t
+	return c
+}
+
+func (p *relativeParser) flush() {
+	for _, o := range p.pending {
+		p.t = addUnit(p.carbon(), o.unit, o.n).t
+	}
+	p.pending = nil
+}
+
+// parseClock parse "9am", "9:30pm", "21:00:05" or "9" followed by "am"/"pm"
+func (p *relativeParser) parseClock(tok string) (bool, error) {
+	m := relativeClockRe.FindStringSubmatch(tok)
+	if m == nil {
+		return false, nil
+	}
+	meridiem := m[4]
+	if meridiem == "" && (p.peek(0) == "am" || p.peek(0) == "pm") {
+		meridiem = p.peek(0)
+		p.pos++
+	}
+	if meridiem == "" && m[2] == "" {
+		return false, nil
+	}
+
+	hour, _ := strconv.Atoi(m[1])
+	// optional groups are empty and parse to 0
+	minute, _ := strconv.Atoi(m[2])
+	second, _ := strconv.Atoi(m[3])
+
+	if meridiem != "" {
+		if hour < 1 || hour > 12 {
+			return true, fmt.Errorf("invalid hour %q", tok)
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	if hour > 23 || minute > 59 || second > 59 {
+		return true, fmt.Errorf("invalid time %q", tok)
+	}
+
+	p.clock = []int{hour, minute, second}
+	return true, nil
+}
+
+// relativeWeekday navigate to weekday, n > 0 strictly after t, n < 0 strictly before t,
+// n == 0 on or after t
+func relativeWeekday(t time.Time, wd time.Weekday, n int) time.Time {
+	diff := (int(wd) - int(t.Weekday()) + 7) % 7
+	switch {
+	case n > 0 && diff == 0:
+		diff = 7
+	case n < 0:
+		diff = -((int(t.Weekday()) - int(wd) + 7) % 7)
+		if diff == 0 {
+			diff = -7
+		}
+	}
+
+	return t.AddDate(0, 0, diff)
+}
+
+func addUnit(c Carbon, u Unit, n int) Carbon {
+	switch u {
+	case Second:
+		return c.Add(time.Duration(n) * time.Second)
+	case Minute:
+		return c.AddMinutes(n)
+	case Hour:
+		return c.AddHours(n)
+	case Day:
+		return c.AddDays(n)
+	case Week:
+		return c.AddWeeks(n)
+	case Month:
+		return c.AddMonths(n)
+	default:
+		return c.AddYears(n)
+	}
+}
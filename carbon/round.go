@@ -0,0 +1,115 @@
+package carbon
+
+import "time"
+
+// FloorTo start of the n unit bucket containing carbon, using the wall clock of its timezone.
+// Buckets are aligned to the enclosing larger unit: seconds, minutes and hours to the start of day,
+// days to the start of month, weeks to the week containing January 1 (honoring WeekStartDay),
+// months to January and years to year 0. n below 1 is treated as 1.
+func (c Carbon) FloorTo(unit Unit, n int) Carbon {
+	c.t, _ = c.bucket(unit, n)
+	return c
+}
+
+// CeilTo start of the next bucket, carbon itself if it is on a bucket boundary,
+// buckets are cut short at the end of the enclosing unit
+func (c Carbon) CeilTo(unit Unit, n int) Carbon {
+	start, next := c.bucket(unit, n)
+	if !start.Equal(c.t) {
+		c.t = next
+	}
+	return c
+}
+
+// RoundTo nearest bucket boundary by elapsed time, halfway rounds up
+func (c Carbon) RoundTo(unit Unit, n int) Carbon {
+	start, next := c.bucket(unit, n)
+	if c.t.Sub(start) < next.Sub(c.t) {
+		c.t = start
+	} else {
+		c.t = next
+	}
+	return c
+}
+
+// bucket start of the bucket containing carbon and start of the next one
+func (c Carbon) bucket(unit Unit, n int) (start, next time.Time) {
+	if n < 1 {
+		n = 1
+	}
+	loc := c.t.Location()
+	year, month, day := c.t.Date()
+
+	switch unit {
+	case Second, Minute, Hour:
+		size := n
+		switch unit {
+		case Minute:
+			size *= 60
+		case Hour:
+			size *= 3600
+		}
+		elapsed := c.t.Hour()*3600 + c.t.Minute()*60 + c.t.Second()
+		floor := elapsed - elapsed%size
+		start = wallOffset(c.t, floor-elapsed, time.Duration(c.t.Nanosecond()))
+		if end := floor + size; end < 86400 {
+			next = wallOffset(c.t, end-elapsed, time.Duration(c.t.Nanosecond()))
+		} else {
+			next = time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+		}
+	case Day:
+		floor := day - (day-1)%n
+		start = time.Date(year, month, floor, 0, 0, 0, 0, loc)
+		next = time.Date(year, month, floor+n, 0, 0, 0, 0, loc)
+		if end := time.Date(year, month+1, 1, 0, 0, 0, 0, loc); next.After(end) {
+			next = end
+		}
+	case Week:
+		startOfWeek := func(year int) time.Time {
+			jan1 := c
+			jan1.t = time.Date(year, time.January, 1, 0, 0, 0, 0, loc)
+			return jan1.StartOfWeek().t
+		}
+		// the last days of December may belong to the first week of next year
+		week := c.StartOfWeek().t
+		if !week.Before(startOfWeek(year + 1)) {
+			year++
+		}
+		first := startOfWeek(year)
+		weeks := daysBetween(first, week) / 7
+		start = first.AddDate(0, 0, (weeks-weeks%n)*7)
+		next = start.AddDate(0, 0, n*7)
+		if end := startOfWeek(year + 1); next.After(end) {
+			next = end
+		}
+	case Month:
+		floor := month - time.Month((int(month)-1)%n)
+		start = time.Date(year, floor, 1, 0, 0, 0, 0, loc)
+		next = time.Date(year, floor+time.Month(n), 1, 0, 0, 0, 0, loc)
+		if end := time.Date(year+1, time.January, 1, 0, 0, 0, 0, loc); next.After(end) {
+			next = end
+		}
+	case Year:
+		floor := year - (year%n+n)%n
+		start = time.Date(floor, time.January, 1, 0, 0, 0, 0, loc)
+		next = time.Date(floor+n, time.January, 1, 0, 0, 0, 0, loc)
+	default:
+		return c.t, c.t
+	}
+
+	return start, next
+}
+
+// wallOffset move t by sec wall clock seconds within its day and drop nanos,
+// keeping the current offset when the result shows the expected wall clock,
+// so instants inside a DST overlap stay in the same occurrence
+func wallOffset(t time.Time, sec int, nanos time.Duration) time.Time {
+	moved := t.Add(time.Duration(sec)*time.Second - nanos)
+	elapsed := t.Hour()*3600 + t.Minute()*60 + t.Second() + sec
+	if moved.Hour()*3600+moved.Minute()*60+moved.Second() == elapsed && moved.Day() == t.Day() {
+		return moved
+	}
+
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, elapsed, 0, t.Location())
+}
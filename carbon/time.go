@@ -3,13 +3,13 @@ package carbon
 import "time"
 
 func GetMillisecond() int64 {
-	return time.Now().UnixNano() / int64(time.Millisecond)
+	return clockNow().UnixNano() / int64(time.Millisecond)
 }
 
 func GetTimestamp() int64 {
-	return time.Now().Unix()
+	return clockNow().Unix()
 }
 
 func FormatNow(format string) string {
-	return time.Now().Format(format)
+	return clockNow().Format(format)
 }
@@ -0,0 +1,32 @@
+package carbon
+
+// Unit calendar or clock unit used by diff, rounding and interval helpers
+type Unit int
+
+const (
+	Second Unit = iota
+	Minute
+	Hour
+	Day
+	Week
+	Month
+	Year
+)
+
+var unitNames = map[Unit]string{
+	Second: "second",
+	Minute: "minute",
+	Hour:   "hour",
+	Day:    "day",
+	Week:   "week",
+	Month:  "month",
+	Year:   "year",
+}
+
+func (u Unit) String() string {
+	if name, ok := unitNames[u]; ok {
+		return name
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,78 @@
+package carbon
+
+import "time"
+
+// Next start of the next weekday strictly after carbon
+func (c Carbon) Next(weekday time.Weekday) Carbon {
+	c.t = relativeWeekday(c.t, weekday, 1)
+	return c.StartOfDay()
+}
+
+// Previous start of the last weekday strictly before carbon
+func (c Carbon) Previous(weekday time.Weekday) Carbon {
+	c.t = relativeWeekday(c.t, weekday, -1)
+	return c.StartOfDay()
+}
+
+// FirstOfMonth start of the first weekday of the month
+func (c Carbon) FirstOfMonth(weekday time.Weekday) Carbon {
+	return firstWeekdayFrom(c.StartOfMonth(), weekday)
+}
+
+// LastOfMonth start of the last weekday of the month
+func (c Carbon) LastOfMonth(weekday time.Weekday) Carbon {
+	return lastWeekdayFrom(c.EndOfMonth(), weekday)
+}
+
+// NthOfMonth start of the nth weekday of the month, false if the month has no nth weekday
+func (c Carbon) NthOfMonth(n int, weekday time.Weekday) (Carbon, bool) {
+	return nthWeekdayBetween(c.StartOfMonth(), c.EndOfMonth(), n, weekday)
+}
+
+func (c Carbon) FirstOfQuarter(weekday time.Weekday) Carbon {
+	return firstWeekdayFrom(c.StartOfQuarter(), weekday)
+}
+
+func (c Carbon) LastOfQuarter(weekday time.Weekday) Carbon {
+	return lastWeekdayFrom(c.EndOfQuarter(), weekday)
+}
+
+func (c Carbon) NthOfQuarter(n int, weekday time.Weekday) (Carbon, bool) {
+	return nthWeekdayBetween(c.StartOfQuarter(), c.EndOfQuarter(), n, weekday)
+}
+
+func (c Carbon) FirstOfYear(weekday time.Weekday) Carbon {
+	return firstWeekdayFrom(c.StartOfYear(), weekday)
+}
+
+func (c Carbon) LastOfYear(weekday time.Weekday) Carbon {
+	return lastWeekdayFrom(c.EndOfYear(), weekday)
+}
+
+func (c Carbon) NthOfYear(n int, weekday time.Weekday) (Carbon, bool) {
+	return nthWeekdayBetween(c.StartOfYear(), c.EndOfYear(), n, weekday)
+}
+
+// firstWeekdayFrom start of the first weekday on or after start
+func firstWeekdayFrom(start Carbon, weekday time.Weekday) Carbon {
+	start.t = relativeWeekday(start.t, weekday, 0)
+	return start.StartOfDay()
+}
+
+// lastWeekdayFrom start of the last weekday on or before end
+func lastWeekdayFrom(end Carbon, weekday time.Weekday) Carbon {
+	end = end.StartOfDay()
+	return end.SubDays((int(end.t.Weekday()) - int(weekday) + 7) % 7)
+}
+
+func nthWeekdayBetween(start, end Carbon, n int, weekday time.Weekday) (Carbon, bool) {
+	if n < 1 {
+		return Carbon{}, false
+	}
+	c := firstWeekdayFrom(start, weekday).AddWeeks(n - 1)
+	if c.Gt(end) {
+		return Carbon{}, false
+	}
+
+	return c, true
+}
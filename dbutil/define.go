@@ -16,15 +16,19 @@ type Datetime struct {
 }
 
 func (c Datetime) MarshalJSON() ([]byte, error) {
+	if !c.Valid {
+		return []byte("null"), nil
+	}
 	return []byte(fmt.Sprintf(`"%s"`, c.GetDateTimeString())), nil
 }
 
 func (c *Datetime) UnmarshalJSON(row []byte) error {
 	s := strings.Trim(string(row), "\"")
-	if s == "" || s == "0001-01-01 00:00:00" {
+	if s == "" || s == "null" || s == "0001-01-01 00:00:00" {
+		*c = Datetime{}
 		return nil
 	}
-	cb, e := carbon.Parse(s, carbon.Timezone)
+	cb, e := carbon.Parse(s, nil)
 	if e == nil {
 		*c = Datetime{Carbon: cb}
 	}
@@ -45,10 +49,11 @@ type Date struct {
 
 func (d *Date) UnmarshalJSON(row []byte) error {
 	s := strings.Trim(string(row), "\"")
-	if s == "" || s == "0000-00-00" {
+	if s == "" || s == "null" || s == "0000-00-00" {
+		*d = Date{}
 		return nil
 	}
-	t, e := time.Parse("2006-01-02", s)
+	t, e := time.ParseInLocation("2006-01-02", s, carbon.DefaultConfig().Timezone)
 	if e != nil {
 		return e
 	}
@@ -57,10 +62,16 @@ func (d *Date) UnmarshalJSON(row []byte) error {
 }
 
 func (d Date) MarshalJSON() ([]byte, error) {
+	if !d.Valid {
+		return []byte("null"), nil
+	}
 	return []byte(fmt.Sprintf(`"%s"`, d.GetDateString())), nil
 }
 
 func (d Date) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
 	return d.GetDateString(), nil
 }
 
@@ -5,6 +5,7 @@ go 1.20
 require (
 	github.com/enorith/http v1.2.3
 	github.com/json-iterator/go v1.1.12
+	gopkg.in/yaml.v3 v3.0.1
 	gorm.io/gorm v1.25.11
 )
 
@@ -16,5 +17,4 @@ require (
 	github.com/modern-go/concurrent v0.0.0-20180228061459-e0a39a4cb421 // indirect
 	github.com/modern-go/reflect2 v1.0.2 // indirect
 	golang.org/x/text v0.14.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )